@@ -0,0 +1,14 @@
+data "devgraph_oidc_endpoints" "okta" {
+  issuer = "https://example.okta.com"
+}
+
+resource "devgraph_oauth_service" "okta" {
+  name          = "okta"
+  display_name  = "Okta"
+  client_id     = var.okta_client_id
+  client_secret = var.okta_client_secret
+
+  authorization_url = data.devgraph_oidc_endpoints.okta.authorization_endpoint
+  token_url         = data.devgraph_oidc_endpoints.okta.token_endpoint
+  userinfo_url      = data.devgraph_oidc_endpoints.okta.userinfo_endpoint
+}
@@ -0,0 +1,17 @@
+# Mint a short-lived token for a provisioner during this run only. The token
+# is revoked when the run finishes (Terraform 1.10+).
+ephemeral "devgraph_api_token" "ci" {
+  name   = "terraform-ci"
+  scopes = ["read"]
+  ttl    = "30m"
+}
+
+resource "terraform_data" "sync" {
+  provisioner "local-exec" {
+    command = "./scripts/sync-entities.sh"
+
+    environment = {
+      DEVGRAPH_TOKEN = ephemeral.devgraph_api_token.ci.token
+    }
+  }
+}
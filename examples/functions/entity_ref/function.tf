@@ -0,0 +1,5 @@
+# Requires Terraform 1.8 or later
+output "api_ref" {
+  # "Component:default/payments-api"
+  value = provider::devgraph::entity_ref("Component", "", "payments-api")
+}
@@ -0,0 +1,11 @@
+# Requires Terraform 1.8 or later
+resource "devgraph_oauth_service" "github" {
+  name          = "github"
+  display_name  = "GitHub"
+  client_id     = var.github_client_id
+  client_secret = var.github_client_secret
+
+  # "https://github.com/login/oauth/authorize"
+  authorization_url = provider::devgraph::normalize_url("HTTPS://GitHub.com:443/login/oauth/authorize/")
+  token_url         = provider::devgraph::normalize_url(var.github_token_url)
+}
@@ -0,0 +1,4 @@
+list "devgraph_discovery_provider" "all" {
+  provider = devgraph
+  limit    = 50
+}
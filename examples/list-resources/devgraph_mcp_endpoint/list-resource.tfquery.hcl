@@ -0,0 +1,7 @@
+list "devgraph_mcp_endpoint" "all" {
+  provider = devgraph
+
+  config {
+    environment = "my-org"
+  }
+}
@@ -0,0 +1,4 @@
+list "devgraph_model_provider" "all" {
+  provider         = devgraph
+  include_resource = true
+}
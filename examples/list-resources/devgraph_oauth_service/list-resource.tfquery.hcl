@@ -0,0 +1,6 @@
+# Find every OAuth service in the provider's environment. Run with
+# `terraform query -generate-config-out=oauth_services.tf` to generate
+# import blocks and resource configuration for them.
+list "devgraph_oauth_service" "all" {
+  provider = devgraph
+}
@@ -38,6 +38,9 @@ resource "devgraph_oauth_service" "github" {
   is_active = true
   icon_url = "https://github.githubassets.com/images/modules/logos_page/GitHub-Mark.png"
   homepage_url = "https://github.com"
+
+  # Refuse to destroy the service until this is set to false and applied
+  deletion_protection = true
 }
 
 variable "github_client_id" {
@@ -56,7 +59,11 @@ resource "devgraph_oauth_service" "gitlab" {
   display_name     = "GitLab"
   description      = "OAuth service for GitLab integration"
   client_id        = var.gitlab_client_id
-  client_secret    = var.gitlab_client_secret
+
+  # Write-only secrets are never stored in state (Terraform 1.11+).
+  # Bump the version to send a rotated secret.
+  client_secret_wo         = var.gitlab_client_secret
+  client_secret_wo_version = 1
   authorization_url = "https://gitlab.com/oauth/authorize"
   token_url        = "https://gitlab.com/oauth/token"
   userinfo_url     = "https://gitlab.com/api/v4/user"
@@ -77,6 +84,7 @@ variable "gitlab_client_id" {
 variable "gitlab_client_secret" {
   type      = string
   sensitive = true
+  ephemeral = true
 }
 
 # Example MCP endpoint with OAuth authentication
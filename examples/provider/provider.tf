@@ -30,3 +30,18 @@ provider "devgraph" {
 #   access_token = var.devgraph_access_token
 #   environment  = var.devgraph_environment
 # }
+
+# Option 4: Use the OAuth2 client credentials grant (tokens are refreshed automatically)
+# provider "devgraph" {
+#   host          = "https://api.devgraph.ai"
+#   client_id     = var.devgraph_client_id
+#   client_secret = var.devgraph_client_secret
+#   token_url     = "https://auth.devgraph.ai/oauth/token"
+#   scopes        = ["devgraph:api"]
+# }
+
+# Option 5: Run an external credentials helper (re-run whenever the token expires)
+# provider "devgraph" {
+#   host                = "https://api.devgraph.ai"
+#   credentials_command = ["devgraph", "auth", "token"]
+# }
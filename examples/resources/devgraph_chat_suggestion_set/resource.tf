@@ -0,0 +1,20 @@
+resource "devgraph_chat_suggestion_set" "default" {
+  suggestions = [
+    {
+      title  = "Analyze codebase structure"
+      label  = "Code Analysis"
+      action = "Please analyze the main repository and provide insights on code quality, architecture, and dependencies."
+    },
+    {
+      title  = "List all microservices"
+      label  = "Services"
+      action = "Show me all microservices in the production environment and their current deployment status."
+    },
+    {
+      title  = "Review security vulnerabilities"
+      label  = "Security"
+      action = "Show me any security vulnerabilities or outdated dependencies that need attention."
+      active = false
+    },
+  ]
+}
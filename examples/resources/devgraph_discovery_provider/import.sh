@@ -0,0 +1,4 @@
+# Discovery providers are imported using their ID or name, optionally prefixed with the environment.
+# The config is not imported because the API masks secrets; it is sent on the next apply.
+terraform import devgraph_discovery_provider.github_example 00000000-0000-0000-0000-000000000000
+terraform import devgraph_discovery_provider.github_example "GitHub Production"
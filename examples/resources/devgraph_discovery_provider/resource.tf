@@ -5,7 +5,6 @@ resource "devgraph_discovery_provider" "github_example" {
   interval      = 300 # 5 minutes (minimum 60 seconds)
 
   config = jsonencode({
-    token = var.github_token
     selectors = [
       {
         organization = "myorg"
@@ -13,6 +12,36 @@ resource "devgraph_discovery_provider" "github_example" {
       }
     ]
   })
+
+  # Write-only credentials are merged into config and never stored in state
+  # (Terraform 1.11+). Bump secrets_version to send rotated credentials.
+  secrets = {
+    token = var.github_token
+  }
+  secrets_version = 1
+}
+
+# Typed configuration blocks can be used instead of config so plans show
+# readable diffs. The token can be set in the block or in secrets.
+resource "devgraph_discovery_provider" "github_typed_example" {
+  name          = "GitHub Platform"
+  provider_type = "github"
+
+  github {
+    organizations = ["myorg", "myorg-labs"]
+  }
+
+  secrets = {
+    token = var.github_token
+  }
+  secrets_version = 1
+
+  # Wait for the first discovery run so that dependents read a populated graph
+  wait_for_first_sync = true
+
+  timeouts {
+    create = "15m"
+  }
 }
 
 resource "devgraph_discovery_provider" "argo_example" {
@@ -21,10 +50,10 @@ resource "devgraph_discovery_provider" "argo_example" {
   enabled       = true
   interval      = 300
 
-  config = jsonencode({
-    api_url = "https://argocd.example.com/api/v1/"
-    token   = var.argo_token
-  })
+  argo {
+    server_url = "https://argocd.example.com/api/v1/"
+    auth_token = var.argo_token
+  }
 }
 
 resource "devgraph_discovery_provider" "docker_example" {
@@ -33,11 +62,12 @@ resource "devgraph_discovery_provider" "docker_example" {
   enabled       = true
   interval      = 600 # 10 minutes
 
-  config = jsonencode({
+  docker {
     registry_type = "ghcr"
     api_url       = "https://ghcr.io/"
     username      = "myusername"
     token         = var.docker_token
+
     selectors = [
       {
         namespace_pattern  = "myorg"
@@ -46,5 +76,6 @@ resource "devgraph_discovery_provider" "docker_example" {
         exclude_tags       = [".*-dev.*", "latest"]
       }
     ]
-  })
+  }
 }
+
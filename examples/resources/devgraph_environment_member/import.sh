@@ -0,0 +1,2 @@
+# Environment members are imported using "<environment_id>/<user_id>"
+terraform import devgraph_environment_member.alice 00000000-0000-0000-0000-000000000000/user_2abc123
@@ -0,0 +1,10 @@
+resource "devgraph_environment_member" "alice" {
+  environment_id = devgraph_environment.production.id
+  email          = "alice@example.com"
+  role           = "admin"
+}
+
+resource "devgraph_environment_member" "bob" {
+  environment_id = devgraph_environment.production.id
+  email          = "bob@example.com"
+}
@@ -0,0 +1,2 @@
+# Environment settings are imported using the environment ID
+terraform import devgraph_environment_settings.production 00000000-0000-0000-0000-000000000000
@@ -0,0 +1,4 @@
+resource "devgraph_environment_settings" "production" {
+  environment_id    = devgraph_environment.production.id
+  discovery_enabled = true
+}
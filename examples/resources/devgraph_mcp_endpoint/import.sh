@@ -0,0 +1,5 @@
+# MCP endpoints are imported using their ID or name, optionally prefixed with the environment
+terraform import devgraph_mcp_endpoint.example 00000000-0000-0000-0000-000000000000
+terraform import devgraph_mcp_endpoint.example my-mcp-server
+terraform import devgraph_mcp_endpoint.example name/my-mcp-server
+terraform import devgraph_mcp_endpoint.example my-org-staging/my-mcp-server
@@ -0,0 +1,4 @@
+# Model providers are imported using their ID or "<type>:<name>", optionally prefixed with the environment
+terraform import devgraph_model_provider.openai 00000000-0000-0000-0000-000000000000
+terraform import devgraph_model_provider.openai openai:my-openai-provider
+terraform import devgraph_model_provider.openai my-org-staging/openai:my-openai-provider
@@ -0,0 +1,7 @@
+# OAuth services are imported using their ID or name, optionally prefixed with the environment.
+# Prefix the name with name/ to look it up by name even if it looks like an ID.
+terraform import devgraph_oauth_service.github 00000000-0000-0000-0000-000000000000
+terraform import devgraph_oauth_service.github github
+terraform import devgraph_oauth_service.github name/github
+terraform import devgraph_oauth_service.github my-org-staging/github
+terraform import devgraph_oauth_service.github my-org-staging/name/github
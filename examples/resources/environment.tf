@@ -7,4 +7,9 @@ resource "devgraph_environment" "example" {
     "user1@example.com",
     "user2@example.com"
   ]
+
+  # Creating an environment provisions an instance, which can take minutes
+  timeouts {
+    create = "15m"
+  }
 }
@@ -12,4 +12,7 @@ resource "devgraph_mcp_endpoint" "example" {
   active             = true
 
   allowed_tools = ["tool1", "tool2"]
+
+  # Fail the create if Devgraph can't connect to the endpoint
+  verify_connection = true
 }
@@ -5,9 +5,12 @@ resource "devgraph_model_provider" "anthropic" {
   default = true
 }
 
+# Write-only keys are never stored in state (Terraform 1.11+).
+# Bump api_key_wo_version to send a rotated key.
 resource "devgraph_model_provider" "openai" {
-  type    = "openai"
-  name    = "my-openai-provider"
-  api_key = var.openai_api_key
-  default = false
+  type               = "openai"
+  name               = "my-openai-provider"
+  api_key_wo         = var.openai_api_key
+  api_key_wo_version = 1
+  default            = false
 }
@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v1 "github.com/arctir/go-devgraph/pkg/apis/devgraph/v1"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &APITokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &APITokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &APITokenEphemeralResource{}
+)
+
+const (
+	defaultAPITokenName = "terraform"
+	defaultAPITokenTTL  = time.Hour
+)
+
+func NewAPITokenEphemeralResource() ephemeral.EphemeralResource {
+	return &APITokenEphemeralResource{}
+}
+
+type APITokenEphemeralResource struct {
+	client *v1.Client
+}
+
+type APITokenEphemeralResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Scopes    types.List   `tfsdk:"scopes"`
+	TTL       types.String `tfsdk:"ttl"`
+	Token     types.String `tfsdk:"token"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+// apiTokenPrivate is kept in private state so the token can be revoked when it is closed
+type apiTokenPrivate struct {
+	ID string `json:"id"`
+}
+
+func (r *APITokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+func (r *APITokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Mints a short-lived Devgraph API token for use by other providers or provisioners during a Terraform run. " +
+			"The token is never stored in state or plan files and is revoked when Terraform no longer needs it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the API token.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the API token. Defaults to \"" + defaultAPITokenName + "\".",
+				Optional:    true,
+			},
+			"scopes": schema.ListAttribute{
+				Description: "The scopes granted to the API token.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"ttl": schema.StringAttribute{
+				Description: "How long the API token is valid for, as a positive duration such as \"30m\" or \"2h\". Defaults to \"1h\". " +
+					"The token is revoked at the end of the run even if it has not expired.",
+				Optional: true,
+			},
+			"token": schema.StringAttribute{
+				Description: "The API token.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "The time at which the API token expires, in RFC 3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *APITokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *APITokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data APITokenEphemeralResourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ttl := parseDurationAttribute(data.TTL, path.Root("ttl"), defaultAPITokenTTL, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if ttl == 0 {
+		// A zero TTL would mint a token that has already expired
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ttl"),
+			"Invalid Duration",
+			fmt.Sprintf("Expected a positive duration such as \"30m\" or \"2h\", got: %q", data.TTL.ValueString()),
+		)
+		return
+	}
+
+	if data.Name.IsNull() {
+		data.Name = types.StringValue(defaultAPITokenName)
+	}
+
+	scopes := []string{}
+	if !data.Scopes.IsNull() {
+		diags = data.Scopes.ElementsAs(ctx, &scopes, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	createReq := v1.ApiTokenCreate{
+		Name:      data.Name.ValueString(),
+		ExpiresAt: v1.NewOptNilString(time.Now().Add(ttl).UTC().Format(time.RFC3339)),
+		Scopes:    scopes,
+	}
+
+	res, err := r.client.CreateToken(withIdempotencyKey(ctx), &createReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating API token",
+			"Could not create API token: "+err.Error(),
+		)
+		return
+	}
+
+	result, ok := res.(*v1.ApiTokenResponse)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected response type",
+			fmt.Sprintf("Expected *v1.ApiTokenResponse, got: %T", res),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(result.ID.String())
+	data.Token = types.StringValue(result.Token)
+	data.ExpiresAt = types.StringValue(result.ExpiresAt.Or(createReq.ExpiresAt.Value))
+
+	if result.Scopes.IsSet() && !result.Scopes.IsNull() {
+		scopeValues := make([]attr.Value, len(result.Scopes.Value))
+		for i, scope := range result.Scopes.Value {
+			scopeValues[i] = types.StringValue(scope)
+		}
+		data.Scopes = types.ListValueMust(types.StringType, scopeValues)
+	}
+
+	private, err := json.Marshal(apiTokenPrivate{ID: result.ID.String()})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error encoding private state",
+			"Could not encode API token ID: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, "token", private)...)
+
+	diags = resp.Result.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *APITokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	private, diags := req.Private.GetKey(ctx, "token")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || private == nil {
+		return
+	}
+
+	var token apiTokenPrivate
+	if err := json.Unmarshal(private, &token); err != nil {
+		resp.Diagnostics.AddError(
+			"Error decoding private state",
+			"Could not decode API token ID: "+err.Error(),
+		)
+		return
+	}
+
+	tokenID, err := uuid.Parse(token.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid API Token ID", err.Error())
+		return
+	}
+
+	_, err = r.client.DeleteToken(ctx, v1.DeleteTokenParams{
+		TokenID: tokenID,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error revoking API token",
+			"Could not revoke API token: "+err.Error(),
+		)
+		return
+	}
+}
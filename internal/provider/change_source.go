@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"os"
+	"strings"
+)
+
+// detectChangeSource describes the automation running Terraform from the environment variables
+// set by Terraform Cloud, Atlantis and GitHub Actions, e.g. "tfc;workspace=prod;run=run-abc123".
+// It returns an empty string when Terraform runs outside a known automation.
+func detectChangeSource() string {
+	switch {
+	case os.Getenv("TFC_RUN_ID") != "":
+		return formatChangeSource("tfc",
+			"workspace", os.Getenv("TFC_WORKSPACE_NAME"),
+			"run", os.Getenv("TFC_RUN_ID"),
+		)
+	case os.Getenv("PULL_NUM") != "" && os.Getenv("BASE_REPO_NAME") != "":
+		return formatChangeSource("atlantis",
+			"repo", os.Getenv("BASE_REPO_OWNER")+"/"+os.Getenv("BASE_REPO_NAME"),
+			"pull", os.Getenv("PULL_NUM"),
+			"workspace", os.Getenv("WORKSPACE"),
+		)
+	case os.Getenv("GITHUB_RUN_ID") != "":
+		return formatChangeSource("github-actions",
+			"repo", os.Getenv("GITHUB_REPOSITORY"),
+			"run", os.Getenv("GITHUB_RUN_ID"),
+		)
+	default:
+		return ""
+	}
+}
+
+// formatChangeSource joins a source name and its non-empty key/value pairs with semicolons
+func formatChangeSource(source string, pairs ...string) string {
+	parts := []string{source}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			parts = append(parts, pairs[i]+"="+pairs[i+1])
+		}
+	}
+	return strings.Join(parts, ";")
+}
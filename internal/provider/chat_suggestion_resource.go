@@ -30,11 +30,13 @@ type ChatSuggestionResource struct {
 }
 
 type ChatSuggestionResourceModel struct {
-	ID     types.String `tfsdk:"id"`
-	Title  types.String `tfsdk:"title"`
-	Label  types.String `tfsdk:"label"`
-	Action types.String `tfsdk:"action"`
-	Active types.Bool   `tfsdk:"active"`
+	ID          types.String `tfsdk:"id"`
+	Title       types.String `tfsdk:"title"`
+	Label       types.String `tfsdk:"label"`
+	Action      types.String `tfsdk:"action"`
+	Active      types.Bool   `tfsdk:"active"`
+	Environment types.String `tfsdk:"environment"`
+	Timeouts    types.Object `tfsdk:"timeouts"`
 }
 
 func (r *ChatSuggestionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,6 +54,7 @@ func (r *ChatSuggestionResource) Schema(ctx context.Context, req resource.Schema
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"environment": environmentAttribute(),
 			"title": schema.StringAttribute{
 				Description: "The title of the suggestion displayed to users.",
 				Required:    true,
@@ -71,6 +74,9 @@ func (r *ChatSuggestionResource) Schema(ctx context.Context, req resource.Schema
 				Default:     booldefault.StaticBool(true),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
 
@@ -79,16 +85,16 @@ func (r *ChatSuggestionResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*v1.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *v1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *ChatSuggestionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -99,6 +105,15 @@ func (r *ChatSuggestionResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, plan.Environment)
+
 	// Build create request
 	createReq := v1.ChatSuggestionCreate{
 		Title:  plan.Title.ValueString(),
@@ -112,7 +127,7 @@ func (r *ChatSuggestionResource) Create(ctx context.Context, req resource.Create
 	}
 
 	// Create chat suggestion
-	res, err := r.client.CreateChatSuggestion(ctx, &createReq)
+	res, err := r.client.CreateChatSuggestion(withIdempotencyKey(ctx), &createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating chat suggestion",
@@ -152,6 +167,15 @@ func (r *ChatSuggestionResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, state.Environment)
+
 	// Parse UUID
 	suggestionID, err := uuid.Parse(state.ID.ValueString())
 	if err != nil {
@@ -211,12 +235,28 @@ func (r *ChatSuggestionResource) Read(ctx context.Context, req resource.ReadRequ
 }
 
 func (r *ChatSuggestionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// According to the API, there's no update endpoint for chat suggestions
-	// To update, we need to delete and recreate
-	resp.Diagnostics.AddError(
-		"Update not supported",
-		"Chat suggestions cannot be updated. Please destroy and recreate the resource to make changes.",
-	)
+	var plan, state ChatSuggestionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// According to the API, there's no update endpoint for chat suggestions,
+	// so only the timeouts, which are never sent to the API, can change
+	if !plan.Title.Equal(state.Title) ||
+		!plan.Label.Equal(state.Label) ||
+		!plan.Action.Equal(state.Action) ||
+		!plan.Active.Equal(state.Active) {
+		resp.Diagnostics.AddError(
+			"Update not supported",
+			"Chat suggestions cannot be updated. Please destroy and recreate the resource to make changes.",
+		)
+		return
+	}
+
+	state.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *ChatSuggestionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -227,6 +267,15 @@ func (r *ChatSuggestionResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, state.Environment)
+
 	// Parse UUID
 	suggestionID, err := uuid.Parse(state.ID.ValueString())
 	if err != nil {
@@ -251,5 +300,5 @@ func (r *ChatSuggestionResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *ChatSuggestionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithEnvironment(ctx, path.Root("id"), req, resp)
 }
@@ -0,0 +1,369 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/arctir/go-devgraph/pkg/apis/devgraph/v1"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &ChatSuggestionSetResource{}
+	_ resource.ResourceWithConfigure   = &ChatSuggestionSetResource{}
+	_ resource.ResourceWithImportState = &ChatSuggestionSetResource{}
+)
+
+func NewChatSuggestionSetResource() resource.Resource {
+	return &ChatSuggestionSetResource{}
+}
+
+type ChatSuggestionSetResource struct {
+	client *v1.Client
+}
+
+type ChatSuggestionSetResourceModel struct {
+	ID          types.String                  `tfsdk:"id"`
+	Suggestions []ChatSuggestionSetEntryModel `tfsdk:"suggestions"`
+	Environment types.String                  `tfsdk:"environment"`
+	Timeouts    types.Object                  `tfsdk:"timeouts"`
+}
+
+type ChatSuggestionSetEntryModel struct {
+	ID     types.String `tfsdk:"id"`
+	Title  types.String `tfsdk:"title"`
+	Label  types.String `tfsdk:"label"`
+	Action types.String `tfsdk:"action"`
+	Active types.Bool   `tfsdk:"active"`
+}
+
+func (r *ChatSuggestionSetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_chat_suggestion_set"
+}
+
+func (r *ChatSuggestionSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the complete, ordered list of chat suggestions in a Devgraph environment. This resource is authoritative: " +
+			"any non-system chat suggestion not listed here is deleted on apply, so it must not be combined with devgraph_chat_suggestion in the same environment.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the chat suggestion set.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment": environmentAttribute(),
+			"suggestions": schema.ListNestedAttribute{
+				Description: "The chat suggestions, in the order they should be shown to users.",
+				Required:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The unique identifier of the chat suggestion.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "The title of the suggestion displayed to users.",
+							Required:    true,
+						},
+						"label": schema.StringAttribute{
+							Description: "A short label or category for the suggestion.",
+							Required:    true,
+						},
+						"action": schema.StringAttribute{
+							Description: "The action or prompt text that will be used when the suggestion is clicked.",
+							Required:    true,
+						},
+						"active": schema.BoolAttribute{
+							Description: "Whether this suggestion is active and should be shown to users.",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(true),
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *ChatSuggestionSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *ChatSuggestionSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ChatSuggestionSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, plan.Environment)
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(uuid.New().String())
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ChatSuggestionSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ChatSuggestionSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, state.Environment)
+
+	current, diags := r.listSuggestions(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Reflect the live list so that additions, removals and reordering made
+	// outside Terraform show up as drift
+	state.Suggestions = make([]ChatSuggestionSetEntryModel, 0, len(current))
+	for _, suggestion := range current {
+		state.Suggestions = append(state.Suggestions, chatSuggestionSetEntryFromResponse(suggestion))
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ChatSuggestionSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ChatSuggestionSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, plan.Environment)
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ChatSuggestionSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ChatSuggestionSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, state.Environment)
+
+	for _, suggestion := range state.Suggestions {
+		suggestionID, err := uuid.Parse(suggestion.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid suggestion ID",
+				"Could not parse suggestion ID as UUID: "+err.Error(),
+			)
+			return
+		}
+
+		_, err = r.client.DeleteChatSuggestion(ctx, v1.DeleteChatSuggestionParams{
+			SuggestionID: suggestionID,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting chat suggestion",
+				"Could not delete chat suggestion: "+err.Error(),
+			)
+			return
+		}
+	}
+}
+
+func (r *ChatSuggestionSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The set covers every suggestion in the environment, so the import ID is
+	// only used as the resource identifier and Read populates the list
+	importStateWithEnvironment(ctx, path.Root("id"), req, resp)
+}
+
+// listSuggestions returns the environment's non-system suggestions, including inactive ones,
+// in the order returned by the API
+func (r *ChatSuggestionSetResource) listSuggestions(ctx context.Context) ([]v1.ChatSuggestionResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	res, err := r.client.ListChatSuggestions(ctx, v1.ListChatSuggestionsParams{
+		ActiveOnly: v1.NewOptBool(false),
+	})
+	if err != nil {
+		diags.AddError(
+			"Error reading chat suggestions",
+			"Could not read chat suggestions: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	listResult, ok := res.(*v1.ListChatSuggestionsOKApplicationJSON)
+	if !ok {
+		diags.AddError(
+			"Unexpected response type",
+			fmt.Sprintf("Expected *v1.ListChatSuggestionsOKApplicationJSON, got: %T", res),
+		)
+		return nil, diags
+	}
+
+	var suggestions []v1.ChatSuggestionResponse
+	for _, suggestion := range *listResult {
+		if suggestion.IsSystem.Value {
+			continue
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, diags
+}
+
+// reconcile makes the live suggestion list match the plan. The API has no
+// update or ordering support and returns suggestions in creation order, so
+// the longest matching prefix is kept and everything after it is recreated.
+func (r *ChatSuggestionSetResource) reconcile(ctx context.Context, plan *ChatSuggestionSetResourceModel) diag.Diagnostics {
+	current, diags := r.listSuggestions(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	keep := 0
+	for keep < len(current) && keep < len(plan.Suggestions) && chatSuggestionSetEntryMatches(plan.Suggestions[keep], current[keep]) {
+		plan.Suggestions[keep].ID = types.StringValue(current[keep].ID.String())
+		keep++
+	}
+
+	for _, suggestion := range current[keep:] {
+		_, err := r.client.DeleteChatSuggestion(ctx, v1.DeleteChatSuggestionParams{
+			SuggestionID: suggestion.ID,
+		})
+		if err != nil {
+			diags.AddError(
+				"Error deleting chat suggestion",
+				"Could not delete chat suggestion: "+err.Error(),
+			)
+			return diags
+		}
+	}
+
+	for i := keep; i < len(plan.Suggestions); i++ {
+		createReq := v1.ChatSuggestionCreate{
+			Title:  plan.Suggestions[i].Title.ValueString(),
+			Label:  plan.Suggestions[i].Label.ValueString(),
+			Action: plan.Suggestions[i].Action.ValueString(),
+			Active: v1.NewOptBool(plan.Suggestions[i].Active.ValueBool()),
+		}
+
+		res, err := r.client.CreateChatSuggestion(withIdempotencyKey(ctx), &createReq)
+		if err != nil {
+			diags.AddError(
+				"Error creating chat suggestion",
+				"Could not create chat suggestion: "+err.Error(),
+			)
+			return diags
+		}
+
+		result, ok := res.(*v1.ChatSuggestionResponse)
+		if !ok {
+			diags.AddError(
+				"Unexpected response type",
+				fmt.Sprintf("Expected *v1.ChatSuggestionResponse, got: %T", res),
+			)
+			return diags
+		}
+
+		plan.Suggestions[i] = chatSuggestionSetEntryFromResponse(*result)
+	}
+
+	return diags
+}
+
+func chatSuggestionSetEntryMatches(entry ChatSuggestionSetEntryModel, suggestion v1.ChatSuggestionResponse) bool {
+	return entry.Title.ValueString() == suggestion.Title &&
+		entry.Label.ValueString() == suggestion.Label &&
+		entry.Action.ValueString() == suggestion.Action &&
+		entry.Active.ValueBool() == suggestion.Active.Or(true)
+}
+
+func chatSuggestionSetEntryFromResponse(suggestion v1.ChatSuggestionResponse) ChatSuggestionSetEntryModel {
+	return ChatSuggestionSetEntryModel{
+		ID:     types.StringValue(suggestion.ID.String()),
+		Title:  types.StringValue(suggestion.Title),
+		Label:  types.StringValue(suggestion.Label),
+		Action: types.StringValue(suggestion.Action),
+		Active: types.BoolValue(suggestion.Active.Or(true)),
+	}
+}
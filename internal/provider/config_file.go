@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+const defaultProfile = "default"
+
+// configProfile is a named set of connection settings in the shared config file
+type configProfile struct {
+	Host        string `yaml:"host"`
+	Token       string `yaml:"token"`
+	Environment string `yaml:"environment"`
+}
+
+// defaultConfigFilePath returns the location of the shared config file, ~/.devgraph/config
+func defaultConfigFilePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".devgraph", "config")
+}
+
+// loadConfigProfile reads a profile from the shared config file, which maps profile names
+// to their settings:
+//
+//	default:
+//	  host: https://api.devgraph.ai
+//	  token: ...
+//	  environment: my-org
+//
+// A missing file or profile is only an error when required is set, i.e. when the user
+// explicitly asked for a file or profile.
+func loadConfigProfile(path string, profile string, required bool) (*configProfile, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !required {
+		return &configProfile{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read config file %s: %w", path, err)
+	}
+
+	var profiles map[string]configProfile
+	if err := yaml.Unmarshal(content, &profiles); err != nil {
+		return nil, fmt.Errorf("could not parse config file %s: %w", path, err)
+	}
+
+	settings, ok := profiles[profile]
+	if !ok {
+		if required {
+			return nil, fmt.Errorf("profile %q not found in config file %s", profile, path)
+		}
+		return &configProfile{}, nil
+	}
+
+	return &settings, nil
+}
@@ -0,0 +1,286 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// credentialsConfig holds the resolved authentication settings of the provider
+type credentialsConfig struct {
+	accessToken  string
+	apiKey       string
+	clientID     string
+	clientSecret string
+	tokenURL     string
+	scopes       []string
+	command      []string
+	tokenFile    string
+
+	// lazy defers running the credentials command or reading the token file
+	// until the first API request
+	lazy bool
+}
+
+// validate checks that the settings are complete for at least one authentication method
+func (c credentialsConfig) validate() diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if c.clientID != "" {
+		if c.clientSecret == "" {
+			diags.AddAttributeError(
+				path.Root("client_secret"),
+				"Missing Devgraph Client Secret",
+				"The provider cannot use the client credentials grant as there is a missing or empty value for the client secret. "+
+					"Set the client_secret value in the configuration or use the DEVGRAPH_CLIENT_SECRET environment variable. ",
+			)
+		}
+
+		if c.tokenURL == "" {
+			diags.AddAttributeError(
+				path.Root("token_url"),
+				"Missing Devgraph Token URL",
+				"The provider cannot use the client credentials grant as there is a missing or empty value for the token URL. "+
+					"Set the token_url value in the configuration or use the DEVGRAPH_TOKEN_URL environment variable. ",
+			)
+		}
+	} else if len(c.command) == 0 && c.tokenFile == "" && c.accessToken == "" && c.apiKey == "" {
+		diags.AddAttributeError(
+			path.Root("access_token"),
+			"Missing Devgraph Access Token",
+			"The provider cannot create the Devgraph API client as there is a missing or empty value for the access token. "+
+				"Set the access_token value in the configuration or use the DEVGRAPH_ACCESS_TOKEN environment variable, "+
+				"set api_key or DEVGRAPH_API_KEY, set credentials_command or token_file, add a token to a profile in the shared config file, "+
+				"or configure client_id, client_secret and token_url to use the client credentials grant. ",
+		)
+	}
+
+	return diags
+}
+
+// newTokenSource returns the token source for the configured authentication method. The client
+// credentials grant takes precedence, followed by the credentials command, the token file and
+// then static tokens.
+func newTokenSource(config credentialsConfig, httpClient *http.Client) (oauth2.TokenSource, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if config.clientID != "" {
+		// The token source outlives the Configure call and refreshes tokens
+		// during long applies, so it must not be bound to the request context
+		tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+		clientCredentials := &clientcredentials.Config{
+			ClientID:     config.clientID,
+			ClientSecret: config.clientSecret,
+			TokenURL:     config.tokenURL,
+			Scopes:       config.scopes,
+		}
+		return &refreshableTokenSource{
+			source: tokenSourceFunc(func() (*oauth2.Token, error) {
+				return clientCredentials.Token(tokenCtx)
+			}),
+		}, diags
+	}
+
+	if len(config.command) > 0 {
+		// Run the helper now so that failures are reported during Configure
+		commandSource := &commandTokenSource{command: config.command}
+		if config.lazy {
+			return &refreshableTokenSource{source: commandSource}, diags
+		}
+		token, err := commandSource.Token()
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("credentials_command"),
+				"Unable to Obtain Devgraph Credentials",
+				"The credentials command could not provide an access token.\n\n"+err.Error(),
+			)
+			return nil, diags
+		}
+		return &refreshableTokenSource{source: commandSource, token: token}, diags
+	}
+
+	if config.tokenFile != "" {
+		// Read the file now so that failures are reported during Configure
+		fileSource := &fileTokenSource{path: config.tokenFile}
+		if config.lazy {
+			return &refreshableTokenSource{source: fileSource}, diags
+		}
+		token, err := fileSource.Token()
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("token_file"),
+				"Unable to Read Devgraph Token File",
+				err.Error(),
+			)
+			return nil, diags
+		}
+		return &refreshableTokenSource{source: fileSource, token: token}, diags
+	}
+
+	// API keys are presented the same way as access tokens, through the
+	// bearer security scheme, so they share the static token source
+	credential := config.accessToken
+	if credential == "" {
+		credential = config.apiKey
+	}
+	return oauth2.StaticTokenSource(&oauth2.Token{
+		AccessToken: credential,
+		TokenType:   "Bearer",
+	}), diags
+}
+
+// commandTokenSource obtains access tokens by running an external credentials helper,
+// similar to kubectl exec credential plugins. The helper must print either a bare token
+// or a JSON object of the form {"access_token": "...", "expires_at": "<RFC 3339 timestamp>"}
+// to stdout.
+type commandTokenSource struct {
+	command []string
+}
+
+type commandCredentials struct {
+	AccessToken string `json:"access_token"`
+	Token       string `json:"token"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+func (s *commandTokenSource) Token() (*oauth2.Token, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command(s.command[0], s.command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("credentials command %q failed: %w: %s", s.command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	token, err := parseCredentials(stdout.String())
+	if err != nil {
+		return nil, fmt.Errorf("credentials command %q: %w", s.command[0], err)
+	}
+
+	return token, nil
+}
+
+// fileTokenSource reads access tokens from a file that is rotated by an external agent.
+// The file holds the same formats as credentials command output. Tokens without an
+// expiry are re-read after tokenFileRefreshInterval so rotations are picked up.
+type fileTokenSource struct {
+	path string
+}
+
+const tokenFileRefreshInterval = time.Minute
+
+func (s *fileTokenSource) Token() (*oauth2.Token, error) {
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read token file: %w", err)
+	}
+
+	token, err := parseCredentials(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("token file %s: %w", s.path, err)
+	}
+
+	if token.Expiry.IsZero() {
+		token.Expiry = time.Now().Add(tokenFileRefreshInterval)
+	}
+
+	return token, nil
+}
+
+// parseCredentials reads either a bare token or a JSON object of the form
+// {"access_token": "...", "expires_at": "<RFC 3339 timestamp>"}
+func parseCredentials(output string) (*oauth2.Token, error) {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return nil, fmt.Errorf("no token found")
+	}
+
+	// Plain output is treated as a token without a known expiry
+	if !strings.HasPrefix(output, "{") {
+		return &oauth2.Token{AccessToken: output, TokenType: "Bearer"}, nil
+	}
+
+	var credentials commandCredentials
+	if err := json.Unmarshal([]byte(output), &credentials); err != nil {
+		return nil, fmt.Errorf("could not parse credentials as JSON: %w", err)
+	}
+
+	token := &oauth2.Token{
+		AccessToken: credentials.AccessToken,
+		TokenType:   "Bearer",
+	}
+	if token.AccessToken == "" {
+		token.AccessToken = credentials.Token
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("no access_token found")
+	}
+
+	if credentials.ExpiresAt != "" {
+		expiry, err := time.Parse(time.RFC3339, credentials.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse expires_at: %w", err)
+		}
+		token.Expiry = expiry
+	}
+
+	return token, nil
+}
+
+// refreshableTokenSource caches tokens from a source that can mint new ones. Unlike
+// oauth2.ReuseTokenSource, the cached token can be discarded when the API rejects it
+// before its reported expiry, e.g. because it was revoked or the clocks disagree.
+type refreshableTokenSource struct {
+	mu     sync.Mutex
+	source oauth2.TokenSource
+	token  *oauth2.Token
+}
+
+func (s *refreshableTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid() {
+		return s.token, nil
+	}
+
+	token, err := s.source.Token()
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+
+	return token, nil
+}
+
+// invalidate discards the cached token if it is still the one that was rejected, so
+// concurrent requests failing with the same token only trigger a single refresh
+func (s *refreshableTokenSource) invalidate(rejected *oauth2.Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && s.token.AccessToken == rejected.AccessToken {
+		s.token = nil
+	}
+}
+
+// tokenSourceFunc adapts a function to the oauth2.TokenSource interface
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
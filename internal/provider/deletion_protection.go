@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute is the schema of the deletion_protection attribute of resources
+// that are costly to lose. It is only stored in state and never sent to the API.
+func deletionProtectionAttribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: fmt.Sprintf("Whether Terraform is prevented from destroying the %s. "+
+			"Set to false and apply before destroying or replacing it. Defaults to false.", kind),
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// checkDeletionProtection returns an error if deletion protection is enabled in state
+func checkDeletionProtection(deletionProtection types.Bool, kind string, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if deletionProtection.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("deletion_protection"),
+			"Deletion protection enabled",
+			fmt.Sprintf("The %s %q cannot be destroyed while deletion_protection is enabled. "+
+				"Set deletion_protection = false and apply before destroying or replacing it.", kind, name),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,511 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	v1 "github.com/arctir/go-devgraph/pkg/apis/devgraph/v1"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// discoveryProviderTypes are the provider types Devgraph supports. The plan also checks the
+// provider type against the server, which rejects types it doesn't know when reading their
+// config schema.
+var discoveryProviderTypes = []string{"github", "gitlab", "argo", "vercel", "docker", "file", "fossa", "meta"}
+
+// discoveryProviderBlocks returns the typed configuration blocks of devgraph_discovery_provider,
+// keyed by the provider type they configure. Each attribute of a block is sent as the config key
+// of the same name, unless discoveryProviderConfigKeys maps it to another.
+func discoveryProviderBlocks() map[string]schema.SingleNestedBlock {
+	return map[string]schema.SingleNestedBlock{
+		"github": {
+			Description: "Typed configuration for the github provider type, as an alternative to config. " +
+				"Archived repository and GitHub Enterprise API URL settings are not offered because the API does not document their config keys; set them in config if needed.",
+			Attributes: map[string]schema.Attribute{
+				"token": schema.StringAttribute{
+					Description: "GitHub token used to read the organizations. Can be left out in favor of a token in secrets.",
+					Optional:    true,
+					Sensitive:   true,
+				},
+				"organizations": schema.ListAttribute{
+					Description: "GitHub organizations to discover every repository of. Sent as the selectors config key, " +
+						"with one selector per organization matching all repository names.",
+					Optional:    true,
+					ElementType: types.StringType,
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(1),
+						listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+					},
+				},
+			},
+			Validators: []validator.Object{
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("organizations")),
+			},
+		},
+		"argo": {
+			Description: "Typed configuration for the argo provider type, as an alternative to config. " +
+				"Project filtering and skipping TLS verification are not offered because the API does not document their config keys; set them in config if needed.",
+			Attributes: map[string]schema.Attribute{
+				"server_url": schema.StringAttribute{
+					Description: "URL of the Argo CD API, e.g. https://argocd.example.com/api/v1/. Sent as the api_url config key.",
+					Optional:    true,
+					Validators: []validator.String{
+						httpURLValidator(),
+					},
+				},
+				"auth_token": schema.StringAttribute{
+					Description: "Argo CD API token. Sent as the token config key, and can be left out in favor of a token in secrets.",
+					Optional:    true,
+					Sensitive:   true,
+				},
+			},
+			Validators: []validator.Object{
+				objectvalidator.AlsoRequires(path.MatchRelative().AtName("server_url")),
+			},
+		},
+		"docker": {
+			Description: "Typed configuration for the docker provider type, as an alternative to config.",
+			Attributes: map[string]schema.Attribute{
+				"registry_type": schema.StringAttribute{
+					Description: "Type of the container registry, e.g. ghcr or dockerhub.",
+					Optional:    true,
+				},
+				"api_url": schema.StringAttribute{
+					Description: "URL of the registry API, e.g. https://ghcr.io/.",
+					Optional:    true,
+					Validators: []validator.String{
+						httpURLValidator(),
+					},
+				},
+				"username": schema.StringAttribute{
+					Description: "Username to authenticate to the registry with.",
+					Optional:    true,
+				},
+				"token": schema.StringAttribute{
+					Description: "Registry token or password. Can be left out in favor of a token in secrets.",
+					Optional:    true,
+					Sensitive:   true,
+				},
+				"selectors": schema.ListNestedAttribute{
+					Description: "Selectors of the repositories and tags to discover.",
+					Optional:    true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"namespace_pattern": schema.StringAttribute{
+								Description: "Regular expression matching the namespaces to discover.",
+								Optional:    true,
+							},
+							"repository_pattern": schema.StringAttribute{
+								Description: "Regular expression matching the repositories to discover.",
+								Optional:    true,
+							},
+							"max_tags": schema.Int64Attribute{
+								Description: "Maximum number of tags to discover per repository.",
+								Optional:    true,
+								Validators: []validator.Int64{
+									int64validator.AtLeast(1),
+								},
+							},
+							"exclude_tags": schema.ListAttribute{
+								Description: "Regular expressions matching tags to leave out.",
+								Optional:    true,
+								ElementType: types.StringType,
+							},
+						},
+					},
+				},
+			},
+			Validators: []validator.Object{
+				objectvalidator.AlsoRequires(
+					path.MatchRelative().AtName("registry_type"),
+					path.MatchRelative().AtName("api_url"),
+				),
+			},
+		},
+	}
+}
+
+// discoveryProviderConfigKeys maps the attributes of typed configuration blocks whose config
+// key has a different name
+var discoveryProviderConfigKeys = map[string]map[string]string{
+	"github": {
+		"organizations": "selectors",
+	},
+	"argo": {
+		"server_url": "api_url",
+		"auth_token": "token",
+	},
+}
+
+// discoveryProviderConfigConverter converts an attribute of a typed configuration block whose
+// config value has a different shape than the attribute
+type discoveryProviderConfigConverter struct {
+	toConfig   func(value any) any
+	fromConfig func(value any) (any, bool)
+}
+
+// discoveryProviderConfigConverters are the converters of typed configuration block attributes,
+// keyed by block and attribute name
+var discoveryProviderConfigConverters = map[string]map[string]discoveryProviderConfigConverter{
+	"github": {
+		"organizations": {toConfig: githubSelectorsFromOrganizations, fromConfig: githubOrganizationsFromSelectors},
+	},
+}
+
+// githubSelectorsFromOrganizations returns the github selectors matching every repository of
+// each organization
+func githubSelectorsFromOrganizations(value any) any {
+	organizations, _ := value.([]any)
+	selectors := make([]any, 0, len(organizations))
+	for _, organization := range organizations {
+		selectors = append(selectors, map[string]any{
+			"organization": organization,
+			"repo_name":    ".*",
+		})
+	}
+	return selectors
+}
+
+// githubOrganizationsFromSelectors returns the organizations of github selectors
+func githubOrganizationsFromSelectors(value any) (any, bool) {
+	selectors, ok := value.([]any)
+	if !ok {
+		return nil, false
+	}
+	organizations := make([]any, 0, len(selectors))
+	for _, selector := range selectors {
+		selector, ok := selector.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		organization, ok := selector["organization"].(string)
+		if !ok {
+			return nil, false
+		}
+		organizations = append(organizations, organization)
+	}
+	return organizations, true
+}
+
+// discoveryProviderConfigKey returns the config key an attribute of a typed configuration
+// block is sent as
+func discoveryProviderConfigKey(name string, attribute string) string {
+	if key, ok := discoveryProviderConfigKeys[name][attribute]; ok {
+		return key
+	}
+	return attribute
+}
+
+// configBlocks returns the typed configuration blocks of the model, keyed by provider type
+func (m DiscoveryProviderResourceModel) configBlocks() map[string]types.Object {
+	return map[string]types.Object{
+		"github": m.GitHub,
+		"argo":   m.Argo,
+		"docker": m.Docker,
+	}
+}
+
+// setConfigBlock sets the typed configuration block for the provider type
+func (m *DiscoveryProviderResourceModel) setConfigBlock(name string, block types.Object) {
+	switch name {
+	case "github":
+		m.GitHub = block
+	case "argo":
+		m.Argo = block
+	case "docker":
+		m.Docker = block
+	}
+}
+
+// discoveryProviderConfigPaths returns the paths of config and the typed configuration blocks,
+// exactly one of which must be set
+func discoveryProviderConfigPaths() []path.Expression {
+	paths := []path.Expression{path.MatchRoot("config")}
+	for name := range discoveryProviderBlocks() {
+		paths = append(paths, path.MatchRoot(name))
+	}
+	return paths
+}
+
+// validateDiscoveryProviderBlocks checks that a typed configuration block is only used with
+// its provider type
+func validateDiscoveryProviderBlocks(model DiscoveryProviderResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if model.ProviderType.IsNull() || model.ProviderType.IsUnknown() {
+		return diags
+	}
+
+	for name, block := range model.configBlocks() {
+		if block.IsNull() || name == model.ProviderType.ValueString() {
+			continue
+		}
+		diags.AddAttributeError(
+			path.Root(name),
+			"Mismatched discovery provider configuration",
+			fmt.Sprintf("The %s block can only be used with provider_type = %q, got: %q.", name, name, model.ProviderType.ValueString()),
+		)
+	}
+
+	return diags
+}
+
+// configValueFromAttribute converts a Terraform value to the value sent in the JSON config
+func configValueFromAttribute(value attr.Value) any {
+	switch value := value.(type) {
+	case types.String:
+		return value.ValueString()
+	case types.Bool:
+		return value.ValueBool()
+	case types.Int64:
+		return value.ValueInt64()
+	case types.List:
+		elements := make([]any, 0, len(value.Elements()))
+		for _, element := range value.Elements() {
+			elements = append(elements, configValueFromAttribute(element))
+		}
+		return elements
+	case types.Object:
+		object := make(map[string]any, len(value.Attributes()))
+		for key, attribute := range value.Attributes() {
+			if !attribute.IsNull() {
+				object[key] = configValueFromAttribute(attribute)
+			}
+		}
+		return object
+	}
+	return nil
+}
+
+// configValueToAttribute converts a value of the JSON config returned by the API to a
+// Terraform value of the given type
+func configValueToAttribute(ctx context.Context, attrType attr.Type, value any) (attr.Value, bool) {
+	switch attrType {
+	case types.StringType:
+		s, ok := value.(string)
+		return types.StringValue(s), ok
+	case types.BoolType:
+		b, ok := value.(bool)
+		return types.BoolValue(b), ok
+	case types.Int64Type:
+		n, ok := value.(float64)
+		return types.Int64Value(int64(n)), ok
+	}
+
+	if objectType, ok := attrType.(types.ObjectType); ok {
+		return configObjectToAttribute(ctx, objectType, value)
+	}
+
+	listType, ok := attrType.(types.ListType)
+	if !ok {
+		return nil, false
+	}
+	values, ok := value.([]any)
+	if !ok {
+		return nil, false
+	}
+	elements := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		element, ok := configValueToAttribute(ctx, listType.ElemType, v)
+		if !ok {
+			return nil, false
+		}
+		elements = append(elements, element)
+	}
+	list, diags := types.ListValue(listType.ElemType, elements)
+	return list, !diags.HasError()
+}
+
+// configObjectToAttribute converts an object of the JSON config returned by the API to a
+// Terraform object. Keys missing from the config are null.
+func configObjectToAttribute(ctx context.Context, objectType types.ObjectType, value any) (attr.Value, bool) {
+	values, ok := value.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	attributes := make(map[string]attr.Value, len(objectType.AttrTypes))
+	for key, attrType := range objectType.AttrTypes {
+		v, ok := values[key]
+		if !ok || v == nil {
+			nullValue, err := attrType.ValueFromTerraform(ctx, tftypes.NewValue(attrType.TerraformType(ctx), nil))
+			if err != nil {
+				return nil, false
+			}
+			attributes[key] = nullValue
+			continue
+		}
+		attribute, ok := configValueToAttribute(ctx, attrType, v)
+		if !ok {
+			return nil, false
+		}
+		attributes[key] = attribute
+	}
+
+	object, diags := types.ObjectValue(objectType.AttrTypes, attributes)
+	return object, !diags.HasError()
+}
+
+// discoveryProviderBlockConfig returns the config keys set by a typed configuration block
+func discoveryProviderBlockConfig(name string, block types.Object) (map[string]any, error) {
+	config := make(map[string]any)
+	for key, value := range block.Attributes() {
+		if value.IsNull() {
+			continue
+		}
+		if value.IsUnknown() {
+			return nil, fmt.Errorf("value of %s is not known yet", key)
+		}
+		configValue := configValueFromAttribute(value)
+		if converter, ok := discoveryProviderConfigConverters[name][key]; ok {
+			configValue = converter.toConfig(configValue)
+		}
+		config[discoveryProviderConfigKey(name, key)] = configValue
+	}
+	return config, nil
+}
+
+// refreshDiscoveryProviderBlock updates the non-sensitive attributes of a typed configuration
+// block from the config returned by the API. Attributes that aren't set in state are left
+// alone, so that defaults filled in by the server don't show up as drift, and so are attributes
+// sent from secrets.
+func refreshDiscoveryProviderBlock(ctx context.Context, name string, block types.Object, live v1.ConfiguredProviderResponseConfig, secretKeys map[string]bool) (types.Object, diag.Diagnostics) {
+	if block.IsNull() || block.IsUnknown() {
+		return block, nil
+	}
+
+	blockSchema := discoveryProviderBlocks()[name]
+	attributes := block.Attributes()
+	for key, value := range attributes {
+		configKey := discoveryProviderConfigKey(name, key)
+		raw, ok := live[configKey]
+		if !ok || value.IsNull() || blockSchema.Attributes[key].IsSensitive() || secretKeys[configKey] {
+			continue
+		}
+
+		var liveValue any
+		if err := json.Unmarshal(raw, &liveValue); err != nil || isMaskedConfigValue(liveValue) {
+			continue
+		}
+		if converter, ok := discoveryProviderConfigConverters[name][key]; ok {
+			if liveValue, ok = converter.fromConfig(liveValue); !ok {
+				continue
+			}
+		}
+		if refreshed, ok := configValueToAttribute(ctx, value.Type(ctx), liveValue); ok {
+			attributes[key] = refreshed
+		}
+	}
+
+	return types.ObjectValue(block.AttributeTypes(ctx), attributes)
+}
+
+// discoveryProviderConfigSchema is the part of the JSON schema of a provider type's config that
+// is checked during plan
+type discoveryProviderConfigSchema struct {
+	Properties           map[string]json.RawMessage `json:"properties"`
+	Required             []string                   `json:"required"`
+	AdditionalProperties json.RawMessage            `json:"additionalProperties"`
+}
+
+// plannedDiscoveryProviderConfigKeys returns the config keys the plan will send, with the path
+// each one is configured at. It reports false if the keys aren't all known yet.
+func plannedDiscoveryProviderConfigKeys(plan DiscoveryProviderResourceModel, secrets types.Map) (map[string]path.Path, bool) {
+	keys := make(map[string]path.Path)
+
+	if plan.Config.IsUnknown() || secrets.IsUnknown() {
+		return nil, false
+	}
+
+	if !plan.Config.IsNull() {
+		var config map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(plan.Config.ValueString()), &config); err != nil {
+			return nil, false
+		}
+		for key := range config {
+			keys[key] = path.Root("config")
+		}
+	}
+
+	for name, block := range plan.configBlocks() {
+		if block.IsUnknown() {
+			return nil, false
+		}
+		for attribute, value := range block.Attributes() {
+			if value.IsUnknown() {
+				return nil, false
+			}
+			if !value.IsNull() {
+				keys[discoveryProviderConfigKey(name, attribute)] = path.Root(name).AtName(attribute)
+			}
+		}
+	}
+
+	for key := range secrets.Elements() {
+		keys[key] = path.Root("secrets").AtMapKey(key)
+	}
+
+	return keys, true
+}
+
+// validateDiscoveryProviderConfig checks the planned config keys against the provider type's
+// config schema. Missing required keys are errors. Keys the schema doesn't declare are errors
+// if the schema forbids additional properties and warnings otherwise, as they are most likely
+// typos that the server ignores.
+func validateDiscoveryProviderConfig(keys map[string]path.Path, configPath path.Path, configSchema discoveryProviderConfigSchema) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, key := range configSchema.Required {
+		if _, ok := keys[key]; !ok {
+			diags.AddAttributeError(
+				configPath,
+				"Missing discovery provider config",
+				fmt.Sprintf("The config of this provider type requires %q, but it is not set.", key),
+			)
+		}
+	}
+
+	if len(configSchema.Properties) == 0 {
+		return diags
+	}
+
+	names := make([]string, 0, len(keys))
+	for key := range keys {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+
+	for _, key := range names {
+		keyPath := keys[key]
+		if _, ok := configSchema.Properties[key]; ok {
+			continue
+		}
+		if string(configSchema.AdditionalProperties) == "false" {
+			diags.AddAttributeError(
+				keyPath,
+				"Unknown discovery provider config",
+				fmt.Sprintf("The config of this provider type does not accept %q.", key),
+			)
+		} else {
+			diags.AddAttributeWarning(
+				keyPath,
+				"Unknown discovery provider config",
+				fmt.Sprintf("The config of this provider type does not declare %q, so it may be ignored.", key),
+			)
+		}
+	}
+
+	return diags
+}
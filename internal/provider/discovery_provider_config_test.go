@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	v1 "github.com/arctir/go-devgraph/pkg/apis/devgraph/v1"
+	"github.com/go-faster/jx"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func githubBlock(t *testing.T, token attr.Value, organizations ...string) types.Object {
+	t.Helper()
+
+	elements := make([]attr.Value, 0, len(organizations))
+	for _, organization := range organizations {
+		elements = append(elements, types.StringValue(organization))
+	}
+
+	block, diags := types.ObjectValue(
+		map[string]attr.Type{
+			"token":         types.StringType,
+			"organizations": types.ListType{ElemType: types.StringType},
+		},
+		map[string]attr.Value{
+			"token":         token,
+			"organizations": types.ListValueMust(types.StringType, elements),
+		},
+	)
+	if diags.HasError() {
+		t.Fatalf("building github block: %v", diags)
+	}
+	return block
+}
+
+func TestDiscoveryProviderBlockConfigGitHub(t *testing.T) {
+	config, err := discoveryProviderBlockConfig("github", githubBlock(t, types.StringNull(), "myorg", "myorg-labs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"selectors":[{"organization":"myorg","repo_name":".*"},{"organization":"myorg-labs","repo_name":".*"}]}`
+	if string(got) != want {
+		t.Errorf("got config %s, want %s", got, want)
+	}
+}
+
+func TestRefreshDiscoveryProviderBlockGitHub(t *testing.T) {
+	ctx := context.Background()
+	live := v1.ConfiguredProviderResponseConfig{
+		"selectors": jx.Raw(`[{"organization":"myorg","repo_name":".*"},{"organization":"other","repo_name":".*"}]`),
+		"token":     jx.Raw(`"********"`),
+	}
+
+	block, diags := refreshDiscoveryProviderBlock(ctx, "github", githubBlock(t, types.StringValue("secret"), "myorg"), live, nil)
+	if diags.HasError() {
+		t.Fatalf("refreshing github block: %v", diags)
+	}
+
+	want := githubBlock(t, types.StringValue("secret"), "myorg", "other")
+	if !block.Equal(want) {
+		t.Errorf("got block %s, want %s", block, want)
+	}
+}
+
+func TestRefreshDiscoveryProviderBlockGitHubMissingKey(t *testing.T) {
+	ctx := context.Background()
+	live := v1.ConfiguredProviderResponseConfig{
+		"token": jx.Raw(`"********"`),
+	}
+
+	prior := githubBlock(t, types.StringValue("secret"), "myorg")
+	block, diags := refreshDiscoveryProviderBlock(ctx, "github", prior, live, nil)
+	if diags.HasError() {
+		t.Fatalf("refreshing github block: %v", diags)
+	}
+
+	if !block.Equal(prior) {
+		t.Errorf("got block %s, want the block from state %s", block, prior)
+	}
+}
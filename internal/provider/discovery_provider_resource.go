@@ -4,10 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
 
 	v1 "github.com/arctir/go-devgraph/pkg/apis/devgraph/v1"
 	"github.com/go-faster/jx"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -15,32 +25,66 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ resource.Resource                = &DiscoveryProviderResource{}
-	_ resource.ResourceWithConfigure   = &DiscoveryProviderResource{}
-	_ resource.ResourceWithImportState = &DiscoveryProviderResource{}
+	_ resource.Resource                     = &DiscoveryProviderResource{}
+	_ resource.ResourceWithConfigure        = &DiscoveryProviderResource{}
+	_ resource.ResourceWithImportState      = &DiscoveryProviderResource{}
+	_ resource.ResourceWithIdentity         = &DiscoveryProviderResource{}
+	_ resource.ResourceWithModifyPlan       = &DiscoveryProviderResource{}
+	_ resource.ResourceWithConfigValidators = &DiscoveryProviderResource{}
+	_ resource.ResourceWithValidateConfig   = &DiscoveryProviderResource{}
+	_ list.ListResourceWithConfigure        = &DiscoveryProviderResource{}
 )
 
 func NewDiscoveryProviderResource() resource.Resource {
 	return &DiscoveryProviderResource{}
 }
 
+func NewDiscoveryProviderListResource() list.ListResource {
+	return &DiscoveryProviderResource{}
+}
+
 type DiscoveryProviderResource struct {
-	client *v1.Client
+	client   *v1.Client
+	defaults resourceDefaults
 }
 
 type DiscoveryProviderResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	ProviderType types.String `tfsdk:"provider_type"`
-	Enabled      types.Bool   `tfsdk:"enabled"`
-	Interval     types.Int64  `tfsdk:"interval"`
-	Config       types.String `tfsdk:"config"`
+	ID               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	ProviderType     types.String `tfsdk:"provider_type"`
+	Enabled          types.Bool   `tfsdk:"enabled"`
+	Interval         types.Int64  `tfsdk:"interval"`
+	Config           types.String `tfsdk:"config"`
+	GitHub           types.Object `tfsdk:"github"`
+	Argo             types.Object `tfsdk:"argo"`
+	Docker           types.Object `tfsdk:"docker"`
+	Secrets          types.Map    `tfsdk:"secrets"`
+	SecretsVersion   types.Int64  `tfsdk:"secrets_version"`
+	Environment      types.String `tfsdk:"environment"`
+	WaitForFirstSync types.Bool   `tfsdk:"wait_for_first_sync"`
+	LastRunStatus    types.String `tfsdk:"last_run_status"`
+	Timeouts         types.Object `tfsdk:"timeouts"`
 }
 
+const (
+	firstSyncPollInterval   = 10 * time.Second
+	defaultFirstSyncTimeout = 30 * time.Minute
+)
+
+// discoveryRunPendingStatuses are the last_run_status values of a discovery run that hasn't
+// finished yet
+var discoveryRunPendingStatuses = map[string]bool{"pending": true, "queued": true, "running": true, "in_progress": true}
+
+// discoveryRunSucceededStatuses are the last_run_status values of a successful discovery run.
+// The API doesn't enumerate the statuses, so any other finished status is treated as a failure.
+var discoveryRunSucceededStatuses = map[string]bool{"success": true, "succeeded": true, "completed": true}
+
 func (r *DiscoveryProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_discovery_provider"
 }
@@ -56,6 +100,7 @@ func (r *DiscoveryProviderResource) Schema(ctx context.Context, req resource.Sch
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"environment": environmentAttribute(),
 			"name": schema.StringAttribute{
 				Description: "Human-readable name for this provider instance (e.g., 'GitHub Production').",
 				Required:    true,
@@ -63,6 +108,9 @@ func (r *DiscoveryProviderResource) Schema(ctx context.Context, req resource.Sch
 			"provider_type": schema.StringAttribute{
 				Description: "Type of provider (github, gitlab, argo, vercel, docker, file, fossa, meta).",
 				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(discoveryProviderTypes...),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -73,36 +121,223 @@ func (r *DiscoveryProviderResource) Schema(ctx context.Context, req resource.Sch
 				Computed:    true,
 				Default:     booldefault.StaticBool(true),
 			},
+			"wait_for_first_sync": schema.BoolAttribute{
+				Description: "Whether creating the provider waits until its first discovery run has completed successfully, " +
+					"so that resources and data sources depending on it read a populated graph. The wait is bounded by timeouts.create, or 30 minutes. " +
+					"If the run fails or doesn't finish in time, the provider is left in state as tainted. Has no effect after creation. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"last_run_status": schema.StringAttribute{
+				Description: "Status of the provider's most recent discovery run, as reported by Devgraph. Null until the first run.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"interval": schema.Int64Attribute{
-				Description: "How often to run discovery, in seconds (minimum 60).",
+				Description: "How often to run discovery, in seconds (minimum 60). Defaults to the provider's resource_defaults.discovery_interval, or 300.",
 				Optional:    true,
 				Computed:    true,
 				Default:     int64default.StaticInt64(300),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(60),
+				},
 			},
 			"config": schema.StringAttribute{
-				Description: "Provider configuration as JSON string. The configuration schema depends on the provider_type. Sensitive values (tokens, API keys) will be encrypted.",
-				Required:    true,
+				Description: "Provider configuration as JSON string. The configuration schema depends on the provider_type. " +
+					"Credentials should be set in secrets so that they are not stored in state. " +
+					"Exactly one of config or a typed configuration block matching provider_type must be set. " +
+					"config stays sensitive for backward compatibility, so plans do not show its changes; " +
+					"for configuration that shows up in plan diffs, set credentials in secrets and use the typed block of the provider type.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"secrets": schema.MapAttribute{
+				Description: "Credentials (tokens, API keys) merged into the top level of config when it is sent to Devgraph, " +
+					"as a write-only value that is never stored in state. Requires Terraform 1.11 or later. " +
+					"Secrets are only sent when the provider is created, config changes or secrets_version changes.",
+				ElementType: types.StringType,
+				Optional:    true,
 				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"secrets_version": schema.Int64Attribute{
+				Description: "The version of secrets. Change it to send rotated credentials to Devgraph.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("secrets")),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+
+	for name, block := range discoveryProviderBlocks() {
+		resp.Schema.Blocks[name] = block
 	}
 }
 
+func (r *DiscoveryProviderResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(discoveryProviderConfigPaths()...),
+	}
+}
+
+func (r *DiscoveryProviderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DiscoveryProviderResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateDiscoveryProviderBlocks(config)...)
+
+	// Disabled providers never run discovery
+	if config.WaitForFirstSync.ValueBool() && !config.Enabled.IsNull() && !config.Enabled.IsUnknown() && !config.Enabled.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("wait_for_first_sync"),
+			"Cannot wait for a disabled discovery provider",
+			"A discovery provider with enabled = false never runs discovery, so wait_for_first_sync must not be set.",
+		)
+	}
+}
+
+func (r *DiscoveryProviderResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resourceIdentitySchema(ctx, req, resp)
+}
+
 func (r *DiscoveryProviderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*v1.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *v1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.defaults = data.resourceDefaults
+}
+
+func (r *DiscoveryProviderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when destroying
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Apply the provider-wide default interval when the resource doesn't set one
+	var interval types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("interval"), &interval)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if interval.IsNull() && !r.defaults.DiscoveryInterval.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("interval"), r.defaults.DiscoveryInterval)...)
+	}
+
+	resp.Diagnostics.Append(r.checkConfigSchema(ctx, req)...)
+}
+
+// checkConfigSchema validates a new or changed config against the config schema the API
+// publishes for the provider type, so that unknown keys and missing required fields are
+// reported during plan instead of failing the apply
+func (r *DiscoveryProviderResource) checkConfigSchema(ctx context.Context, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Not configured yet, e.g. during validation
+	if r.client == nil {
+		return diags
+	}
+
+	var plan DiscoveryProviderResourceModel
+	diags.Append(req.Plan.Get(ctx, &plan)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state DiscoveryProviderResourceModel
+		diags.Append(req.State.Get(ctx, &state)...)
+		if diags.HasError() {
+			return diags
+		}
+		if plan.ProviderType.Equal(state.ProviderType) && !discoveryProviderConfigChanged(plan, state) &&
+			plan.SecretsVersion.Equal(state.SecretsVersion) {
+			return diags
+		}
+	}
+
+	if plan.ProviderType.IsUnknown() || plan.ProviderType.IsNull() {
+		return diags
+	}
+
+	var secrets types.Map
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("secrets"), &secrets)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	keys, ok := plannedDiscoveryProviderConfigKeys(plan, secrets)
+	if !ok {
+		return diags
+	}
+
+	ctx = withEnvironment(ctx, plan.Environment)
+	res, err := r.client.GetDiscoveryProviderConfigSchema(ctx, v1.GetDiscoveryProviderConfigSchemaParams{
+		ProviderType: plan.ProviderType.ValueString(),
+	})
+	if err != nil {
+		diags.AddWarning(
+			"Could not validate discovery provider config",
+			"Unable to read the config schema of the provider type, so the config will only be validated on apply: "+err.Error(),
+		)
+		return diags
+	}
+
+	var configSchema discoveryProviderConfigSchema
+	switch body := res.(type) {
+	case *v1.GetDiscoveryProviderConfigSchemaOKApplicationJSON:
+		if err := json.Unmarshal(*body, &configSchema); err != nil {
+			diags.AddWarning(
+				"Could not validate discovery provider config",
+				"Unable to parse the config schema of the provider type, so the config will only be validated on apply: "+err.Error(),
+			)
+			return diags
+		}
+	case *v1.GetDiscoveryProviderConfigSchemaNotFound:
+		diags.AddAttributeError(
+			path.Root("provider_type"),
+			"Unknown provider type",
+			fmt.Sprintf("Devgraph has no discovery provider type %q.", plan.ProviderType.ValueString()),
+		)
+		return diags
+	default:
+		diags.AddWarning(
+			"Could not validate discovery provider config",
+			fmt.Sprintf("Unexpected response type reading the config schema: %T", res),
+		)
+		return diags
+	}
+
+	configPath := path.Root("config")
+	for name, block := range plan.configBlocks() {
+		if !block.IsNull() {
+			configPath = path.Root(name)
+		}
+	}
+
+	diags.Append(validateDiscoveryProviderConfig(keys, configPath, configSchema)...)
+	return diags
 }
 
 func (r *DiscoveryProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -113,38 +348,33 @@ func (r *DiscoveryProviderResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	// Parse config JSON into map[string]jx.Raw
-	configJSON := []byte(plan.Config.ValueString())
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Validate it's valid JSON first
-	var validateMap map[string]interface{}
-	if err := json.Unmarshal(configJSON, &validateMap); err != nil {
-		resp.Diagnostics.AddError(
-			"Invalid Config JSON",
-			"Could not parse config as JSON: "+err.Error(),
-		)
+	ctx = withEnvironment(ctx, plan.Environment)
+
+	configMap, diags := buildDiscoveryProviderConfig(ctx, plan, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Convert to map[string]jx.Raw
-	configMap := make(v1.ConfiguredProviderCreateConfig)
-	for key, value := range validateMap {
-		valueJSON, err := json.Marshal(value)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error encoding config value",
-				fmt.Sprintf("Could not encode value for key %s: %v", key, err),
-			)
-			return
-		}
-		configMap[key] = jx.Raw(valueJSON)
+	secretKeys, diags := discoveryProviderSecretKeys(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, discoverySecretKeysPrivateKey, secretKeys)...)
 
 	// Build create request
 	createReq := v1.ConfiguredProviderCreate{
 		Name:         plan.Name.ValueString(),
 		ProviderType: plan.ProviderType.ValueString(),
-		Config:       configMap,
+		Config:       v1.ConfiguredProviderCreateConfig(configMap),
 	}
 
 	if !plan.Enabled.IsNull() {
@@ -158,7 +388,7 @@ func (r *DiscoveryProviderResource) Create(ctx context.Context, req resource.Cre
 	}
 
 	// Create provider
-	res, err := r.client.CreateConfiguredProvider(ctx, &createReq)
+	res, err := r.client.CreateConfiguredProvider(withIdempotencyKey(ctx), &createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating discovery provider",
@@ -168,14 +398,17 @@ func (r *DiscoveryProviderResource) Create(ctx context.Context, req resource.Cre
 	}
 
 	// Type assert the response - handle different response types
+	var providerID uuid.UUID
 	switch result := res.(type) {
 	case *v1.ConfiguredProviderResponse:
 		// Success case
+		providerID = result.ID
 		plan.ID = types.StringValue(result.ID.String())
 		plan.Name = types.StringValue(result.Name)
 		plan.ProviderType = types.StringValue(result.ProviderType)
 		plan.Enabled = types.BoolValue(result.Enabled)
 		plan.Interval = types.Int64Value(int64(result.Interval))
+		plan.LastRunStatus = lastRunStatusValue(result.LastRunStatus)
 	case *v1.CreateConfiguredProviderNotFound:
 		resp.Diagnostics.AddError(
 			"Provider type not found",
@@ -193,10 +426,115 @@ func (r *DiscoveryProviderResource) Create(ctx context.Context, req resource.Cre
 	// Keep the original config in state (not the masked one from response)
 	// This allows Terraform to detect config changes
 
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.ID, plan.Environment)...)
+
+	// The provider is saved to state even if the wait fails, so that it is tainted
+	// rather than lost
+	if plan.WaitForFirstSync.ValueBool() {
+		plan.LastRunStatus, diags = r.waitForFirstSync(ctx, providerID, plan.Name.ValueString())
+		resp.Diagnostics.Append(diags...)
+	}
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+// waitForFirstSync polls a newly created discovery provider until its first discovery run has
+// finished and returns the run's status. A failed run, or one that doesn't finish before the
+// create timeout or defaultFirstSyncTimeout, is reported as an error.
+func (r *DiscoveryProviderResource) waitForFirstSync(ctx context.Context, providerID uuid.UUID, name string) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	status := types.StringNull()
+
+	if !hasOperationTimeout(ctx) {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultFirstSyncTimeout)
+		defer cancel()
+	}
+
+	timedOut := func() (types.String, diag.Diagnostics) {
+		diags.AddError(
+			"Timed out waiting for first discovery run",
+			fmt.Sprintf("The discovery provider %q was created, but its first discovery run did not finish in time. "+
+				"Increase timeouts.create, or set wait_for_first_sync = false to not wait.", name),
+		)
+		return status, diags
+	}
+
+	for {
+		res, err := r.client.GetConfiguredProvider(ctx, v1.GetConfiguredProviderParams{
+			ProviderID: providerID,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return timedOut()
+			}
+			diags.AddError(
+				"Error waiting for first discovery run",
+				"Could not read discovery provider: "+err.Error(),
+			)
+			return status, diags
+		}
+
+		result, ok := res.(*v1.ConfiguredProviderResponse)
+		if !ok {
+			diags.AddError(
+				"Unexpected response type",
+				fmt.Sprintf("Expected *v1.ConfiguredProviderResponse, got: %T", res),
+			)
+			return status, diags
+		}
+
+		status = lastRunStatusValue(result.LastRunStatus)
+		message, _ := result.LastErrorMessage.Get()
+		done, checkDiags := checkFirstSync(name, status, message)
+		diags.Append(checkDiags...)
+		if done || diags.HasError() {
+			return status, diags
+		}
+
+		timer := time.NewTimer(firstSyncPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return timedOut()
+		case <-timer.C:
+		}
+	}
+}
+
+// checkFirstSync reports whether a discovery provider's first run has succeeded given its last
+// run status. Runs that haven't started or are pending aren't done yet; runs that finished with
+// any status other than a known success are reported as errors with the raw status.
+func checkFirstSync(name string, status types.String, errorMessage string) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if status.IsNull() || discoveryRunPendingStatuses[status.ValueString()] {
+		return false, diags
+	}
+	if discoveryRunSucceededStatuses[status.ValueString()] {
+		return true, diags
+	}
+
+	detail := fmt.Sprintf("The discovery provider %q was created, but its first discovery run finished with status %q.",
+		name, status.ValueString())
+	if errorMessage != "" {
+		detail += " " + errorMessage
+	}
+	diags.AddError("First discovery run did not succeed", detail)
+	return false, diags
+}
+
+// lastRunStatusValue returns the status of a discovery provider's most recent run, or null if
+// it hasn't run yet
+func lastRunStatusValue(status v1.OptNilString) types.String {
+	value, ok := status.Get()
+	if !ok {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
 func (r *DiscoveryProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state DiscoveryProviderResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -205,6 +543,15 @@ func (r *DiscoveryProviderResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, state.Environment)
+
 	// Parse UUID
 	providerID, err := uuid.Parse(state.ID.ValueString())
 	if err != nil {
@@ -215,6 +562,21 @@ func (r *DiscoveryProviderResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
+	// Keys sent from secrets are never read back into state
+	secretKeys := make(map[string]bool)
+	private, diags := req.Private.GetKey(ctx, discoverySecretKeysPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if len(private) > 0 {
+		var keys []string
+		if err := json.Unmarshal(private, &keys); err != nil {
+			resp.Diagnostics.AddError("Error reading private state", "Could not parse the discovery provider secret keys: "+err.Error())
+			return
+		}
+		for _, key := range keys {
+			secretKeys[key] = true
+		}
+	}
+
 	// Get provider
 	res, err := r.client.GetConfiguredProvider(ctx, v1.GetConfiguredProviderParams{
 		ProviderID: providerID,
@@ -235,6 +597,34 @@ func (r *DiscoveryProviderResource) Read(ctx context.Context, req resource.ReadR
 		state.ProviderType = types.StringValue(result.ProviderType)
 		state.Enabled = types.BoolValue(result.Enabled)
 		state.Interval = types.Int64Value(int64(result.Interval))
+		state.LastRunStatus = lastRunStatusValue(result.LastRunStatus)
+		if state.WaitForFirstSync.IsNull() {
+			state.WaitForFirstSync = types.BoolValue(false)
+		}
+
+		// The API masks secrets, so only the unmasked values are compared with
+		// state and masked ones keep their value from state
+		if !state.Config.IsNull() {
+			config, err := refreshDiscoveryProviderConfig(state.Config.ValueString(), result.Config, secretKeys)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("config"),
+					"Error reading discovery provider config",
+					"Could not compare the discovery provider config with state: "+err.Error(),
+				)
+				return
+			}
+			state.Config = types.StringValue(config)
+		}
+
+		for name, block := range state.configBlocks() {
+			block, diags = refreshDiscoveryProviderBlock(ctx, name, block, result.Config, secretKeys)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			state.setConfigBlock(name, block)
+		}
 	case *v1.GetConfiguredProviderNotFound:
 		// Resource doesn't exist - remove from state
 		resp.State.RemoveResource(ctx)
@@ -247,8 +637,7 @@ func (r *DiscoveryProviderResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	// Keep the config from state since the API returns masked secrets
-	// This prevents Terraform from thinking the config has changed
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.ID, state.Environment)...)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -262,6 +651,15 @@ func (r *DiscoveryProviderResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, plan.Environment)
+
 	var state DiscoveryProviderResourceModel
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -297,35 +695,23 @@ func (r *DiscoveryProviderResource) Update(ctx context.Context, req resource.Upd
 		updateReq.SetInterval(v1.NewOptNilInt(interval))
 	}
 
-	if !plan.Config.Equal(state.Config) {
-		// Parse config JSON
-		configJSON := []byte(plan.Config.ValueString())
-
-		// Validate it's valid JSON first
-		var validateMap map[string]interface{}
-		if err := json.Unmarshal(configJSON, &validateMap); err != nil {
-			resp.Diagnostics.AddError(
-				"Invalid Config JSON",
-				"Could not parse config as JSON: "+err.Error(),
-			)
+	// The secrets can't be compared with the previous ones, so the config is
+	// only resent for them when their version changes
+	if discoveryProviderConfigChanged(plan, state) || !plan.SecretsVersion.Equal(state.SecretsVersion) {
+		configMap, diags := buildDiscoveryProviderConfig(ctx, plan, req.Config)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 
-		// Convert to map[string]jx.Raw
-		configMap := make(v1.ConfiguredProviderUpdateConfig)
-		for key, value := range validateMap {
-			valueJSON, err := json.Marshal(value)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error encoding config value",
-					fmt.Sprintf("Could not encode value for key %s: %v", key, err),
-				)
-				return
-			}
-			configMap[key] = jx.Raw(valueJSON)
+		secretKeys, diags := discoveryProviderSecretKeys(ctx, req.Config)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, discoverySecretKeysPrivateKey, secretKeys)...)
 
-		updateReq.SetConfig(v1.NewOptNilConfiguredProviderUpdateConfig(configMap))
+		updateReq.SetConfig(v1.NewOptNilConfiguredProviderUpdateConfig(v1.ConfiguredProviderUpdateConfig(configMap)))
 	}
 
 	// Update provider
@@ -359,6 +745,8 @@ func (r *DiscoveryProviderResource) Update(ctx context.Context, req resource.Upd
 
 	// Keep the config from plan since API returns masked secrets
 
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.ID, plan.Environment)...)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -371,6 +759,15 @@ func (r *DiscoveryProviderResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, state.Environment)
+
 	// Parse UUID
 	providerID, err := uuid.Parse(state.ID.ValueString())
 	if err != nil {
@@ -395,5 +792,296 @@ func (r *DiscoveryProviderResource) Delete(ctx context.Context, req resource.Del
 }
 
 func (r *DiscoveryProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithLookup(ctx, req, resp, r.lookupByName)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API masks secrets in the config, so Read only fills in the values it
+	// returns unmasked. Masked values are sent from the configuration on the
+	// next apply.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("config"), "{}")...)
+	resp.Diagnostics.AddWarning(
+		"Discovery provider secrets not imported",
+		"The Devgraph API does not return discovery provider secrets, so config was imported without them. "+
+			"Set config (and secrets) in the resource configuration; the next apply will send them to Devgraph.",
+	)
+}
+
+// lookupByName returns the ID of the discovery provider with the given name
+func (r *DiscoveryProviderResource) lookupByName(ctx context.Context, name string) (string, diag.Diagnostics) {
+	providers, diags := r.listProviders(ctx)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	candidates := make([]importMatch, 0, len(providers))
+	for _, provider := range providers {
+		candidates = append(candidates, importMatch{id: provider.ID.String(), name: provider.Name})
+	}
+
+	return matchImportName("discovery provider", name, candidates)
+}
+
+func (r *DiscoveryProviderResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	listConfigSchema(ctx, req, resp)
+}
+
+func (r *DiscoveryProviderResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config listConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	providers, diags := r.listProviders(withEnvironment(ctx, config.Environment))
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// Config is left out as the API masks its secrets, the same as on import
+	items := make([]listItem, 0, len(providers))
+	for _, provider := range providers {
+		items = append(items, listItem{
+			id:          provider.ID.String(),
+			displayName: provider.Name,
+			attributes: map[string]attr.Value{
+				"name":            types.StringValue(provider.Name),
+				"provider_type":   types.StringValue(provider.ProviderType),
+				"enabled":         types.BoolValue(provider.Enabled),
+				"interval":        types.Int64Value(int64(provider.Interval)),
+				"last_run_status": lastRunStatusValue(provider.LastRunStatus),
+			},
+		})
+	}
+
+	streamListResults(ctx, req, stream, config.Environment, items)
+}
+
+// listProviders returns all discovery providers configured in the environment
+func (r *DiscoveryProviderResource) listProviders(ctx context.Context) ([]v1.ConfiguredProviderResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	res, err := r.client.ListConfiguredProviders(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error reading discovery providers",
+			"Could not list discovery providers: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	result, ok := res.(*v1.ConfiguredProvidersListResponse)
+	if !ok {
+		diags.AddError(
+			"Unexpected response type",
+			fmt.Sprintf("Expected *v1.ConfiguredProvidersListResponse, got: %T", res),
+		)
+		return nil, diags
+	}
+
+	return result.Providers, diags
+}
+
+// buildDiscoveryProviderConfig builds the config sent to the API from either the JSON config or
+// the typed configuration block, merging in the write-only secrets, which are only available in
+// the configuration. Secrets take precedence over config keys of the same name.
+func buildDiscoveryProviderConfig(ctx context.Context, plan DiscoveryProviderResourceModel, tfConfig tfsdk.Config) (map[string]jx.Raw, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var validateMap map[string]interface{}
+	if !plan.Config.IsNull() {
+		if err := json.Unmarshal([]byte(plan.Config.ValueString()), &validateMap); err != nil {
+			diags.AddError(
+				"Invalid Config JSON",
+				"Could not parse config as JSON: "+err.Error(),
+			)
+			return nil, diags
+		}
+	}
+
+	for name, block := range plan.configBlocks() {
+		if block.IsNull() {
+			continue
+		}
+		blockConfig, err := discoveryProviderBlockConfig(name, block)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root(name),
+				"Invalid discovery provider configuration",
+				err.Error(),
+			)
+			return nil, diags
+		}
+		validateMap = blockConfig
+	}
+
+	// Convert to map[string]jx.Raw
+	configMap := make(map[string]jx.Raw, len(validateMap))
+	for key, value := range validateMap {
+		valueJSON, err := json.Marshal(value)
+		if err != nil {
+			diags.AddError(
+				"Error encoding config value",
+				fmt.Sprintf("Could not encode value for key %s: %v", key, err),
+			)
+			return nil, diags
+		}
+		configMap[key] = jx.Raw(valueJSON)
+	}
+
+	var secrets map[string]string
+	diags.Append(tfConfig.GetAttribute(ctx, path.Root("secrets"), &secrets)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for key, value := range secrets {
+		valueJSON, err := json.Marshal(value)
+		if err != nil {
+			diags.AddError(
+				"Error encoding secret value",
+				fmt.Sprintf("Could not encode value for secret %s: %v", key, err),
+			)
+			return nil, diags
+		}
+		configMap[key] = jx.Raw(valueJSON)
+	}
+
+	return configMap, diags
+}
+
+// discoverySecretKeysPrivateKey is the private state key holding the names of the config keys
+// sent from secrets, so that Read can leave them out of the config stored in state
+const discoverySecretKeysPrivateKey = "secret_keys"
+
+// discoveryProviderSecretKeys returns the names of the secrets in the configuration, encoded
+// for private state
+func discoveryProviderSecretKeys(ctx context.Context, tfConfig tfsdk.Config) ([]byte, diag.Diagnostics) {
+	var secrets map[string]string
+	diags := tfConfig.GetAttribute(ctx, path.Root("secrets"), &secrets)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	keys := make([]string, 0, len(secrets))
+	for key := range secrets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	private, err := json.Marshal(keys)
+	if err != nil {
+		diags.AddError("Error encoding private state", "Could not encode the discovery provider secret keys: "+err.Error())
+	}
+	return private, diags
+}
+
+// refreshDiscoveryProviderConfig returns the config to store in state given the config from
+// state and the config returned by the API. Keys sent from secrets and values masked by the API
+// keep their value from state, or are left out if state has none, so that neither secrets nor
+// mask placeholders are stored. Keys the API leaves out of its response, such as defaults it
+// doesn't echo back, keep their value from state too, so only keys present on both sides can
+// drift. The config from state is returned unchanged when nothing drifted, to keep its formatting.
+func refreshDiscoveryProviderConfig(prior string, live v1.ConfiguredProviderResponseConfig, secretKeys map[string]bool) (string, error) {
+	var priorConfig map[string]any
+	if err := json.Unmarshal([]byte(prior), &priorConfig); err != nil {
+		return "", err
+	}
+
+	liveConfig := make(map[string]any, len(live))
+	for key, raw := range live {
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return "", fmt.Errorf("could not parse value of %s: %w", key, err)
+		}
+		priorValue, inState := priorConfig[key]
+		if secretKeys[key] {
+			if inState {
+				liveConfig[key] = priorValue
+			}
+			continue
+		}
+		if !inState && isMaskedConfigValue(value) {
+			continue
+		}
+		liveConfig[key] = unmaskConfigValue(priorValue, value)
+	}
+	for key, priorValue := range priorConfig {
+		if _, ok := live[key]; !ok {
+			liveConfig[key] = priorValue
+		}
+	}
+
+	if reflect.DeepEqual(priorConfig, liveConfig) {
+		return prior, nil
+	}
+
+	config, err := json.Marshal(liveConfig)
+	if err != nil {
+		return "", err
+	}
+	return string(config), nil
+}
+
+// unmaskConfigValue returns the live value with every masked value replaced by the value
+// at the same position in the prior config. Masked object keys without a prior value are
+// removed; masked list elements without one are kept so the list keeps its length. Object
+// keys missing from the live value keep their prior value.
+func unmaskConfigValue(prior any, live any) any {
+	if isMaskedConfigValue(live) && prior != nil {
+		return prior
+	}
+
+	switch live := live.(type) {
+	case map[string]any:
+		priorMap, _ := prior.(map[string]any)
+		for key, value := range live {
+			priorValue, ok := priorMap[key]
+			if !ok && isMaskedConfigValue(value) {
+				delete(live, key)
+				continue
+			}
+			live[key] = unmaskConfigValue(priorValue, value)
+		}
+		for key, priorValue := range priorMap {
+			if _, ok := live[key]; !ok {
+				live[key] = priorValue
+			}
+		}
+	case []any:
+		priorList, _ := prior.([]any)
+		for i, value := range live {
+			var priorValue any
+			if i < len(priorList) {
+				priorValue = priorList[i]
+			}
+			live[i] = unmaskConfigValue(priorValue, value)
+		}
+	}
+
+	return live
+}
+
+// isMaskedConfigValue reports whether the API replaced a value with a mask such as "********"
+func isMaskedConfigValue(value any) bool {
+	s, ok := value.(string)
+	return ok && s != "" && strings.Trim(s, "*") == ""
+}
+
+// discoveryProviderConfigChanged reports whether the JSON config or any typed configuration
+// block differs between the plan and state
+func discoveryProviderConfigChanged(plan, state DiscoveryProviderResourceModel) bool {
+	if !plan.Config.Equal(state.Config) {
+		return true
+	}
+	stateBlocks := state.configBlocks()
+	for name, block := range plan.configBlocks() {
+		if !block.Equal(stateBlocks[name]) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	v1 "github.com/arctir/go-devgraph/pkg/apis/devgraph/v1"
+	"github.com/go-faster/jx"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCheckFirstSync(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  types.String
+		message string
+		done    bool
+		err     string
+	}{
+		{name: "not run yet", status: types.StringNull()},
+		{name: "running", status: types.StringValue("running")},
+		{name: "succeeded", status: types.StringValue("success"), done: true},
+		{name: "failed", status: types.StringValue("failed"), message: "bad credentials", err: `status "failed". bad credentials`},
+		{name: "unknown status", status: types.StringValue("cancelled"), err: `status "cancelled"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			done, diags := checkFirstSync("example", test.status, test.message)
+			if done != test.done {
+				t.Errorf("got done %t, want %t", done, test.done)
+			}
+
+			if test.err == "" {
+				if diags.HasError() {
+					t.Errorf("unexpected error: %v", diags)
+				}
+				return
+			}
+			if !diags.HasError() {
+				t.Fatalf("expected an error containing %q", test.err)
+			}
+			if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, test.err) {
+				t.Errorf("got error %q, want it to contain %q", detail, test.err)
+			}
+		})
+	}
+}
+
+func TestRefreshDiscoveryProviderConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		prior      string
+		live       v1.ConfiguredProviderResponseConfig
+		secretKeys map[string]bool
+		want       string
+	}{
+		{
+			name:  "unchanged",
+			prior: `{"api_url": "https://argocd.example.com/api/v1/"}`,
+			live:  v1.ConfiguredProviderResponseConfig{"api_url": jx.Raw(`"https://argocd.example.com/api/v1/"`)},
+			want:  `{"api_url": "https://argocd.example.com/api/v1/"}`,
+		},
+		{
+			name:  "drifted",
+			prior: `{"api_url":"https://argocd.example.com/api/v1/"}`,
+			live:  v1.ConfiguredProviderResponseConfig{"api_url": jx.Raw(`"https://argo.example.com/api/v1/"`)},
+			want:  `{"api_url":"https://argo.example.com/api/v1/"}`,
+		},
+		{
+			name:  "masked value kept from state",
+			prior: `{"api_url":"https://argocd.example.com/api/v1/","token":"secret"}`,
+			live: v1.ConfiguredProviderResponseConfig{
+				"api_url": jx.Raw(`"https://argocd.example.com/api/v1/"`),
+				"token":   jx.Raw(`"********"`),
+			},
+			want: `{"api_url":"https://argocd.example.com/api/v1/","token":"secret"}`,
+		},
+		{
+			name:       "secret left out",
+			prior:      `{"api_url":"https://argocd.example.com/api/v1/"}`,
+			live:       v1.ConfiguredProviderResponseConfig{"api_url": jx.Raw(`"https://argocd.example.com/api/v1/"`), "token": jx.Raw(`"live"`)},
+			secretKeys: map[string]bool{"token": true},
+			want:       `{"api_url":"https://argocd.example.com/api/v1/"}`,
+		},
+		{
+			name:  "key missing from live kept from state",
+			prior: `{"api_url":"https://argocd.example.com/api/v1/","timeout":30}`,
+			live:  v1.ConfiguredProviderResponseConfig{"api_url": jx.Raw(`"https://argocd.example.com/api/v1/"`)},
+			want:  `{"api_url":"https://argocd.example.com/api/v1/","timeout":30}`,
+		},
+		{
+			name:  "nested key missing from live kept from state",
+			prior: `{"selectors":[{"organization":"myorg","repo_name":".*"}]}`,
+			live:  v1.ConfiguredProviderResponseConfig{"selectors": jx.Raw(`[{"organization":"myorg"}]`)},
+			want:  `{"selectors":[{"organization":"myorg","repo_name":".*"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := refreshDiscoveryProviderConfig(test.prior, test.live, test.secretKeys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got config %s, want %s", got, test.want)
+			}
+		})
+	}
+}
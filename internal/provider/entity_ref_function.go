@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &EntityRefFunction{}
+
+const defaultEntityNamespace = "default"
+
+var (
+	entityKindPattern      = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+	entityNamespacePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+	entityNamePattern      = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?$`)
+)
+
+func NewEntityRefFunction() function.Function {
+	return &EntityRefFunction{}
+}
+
+type EntityRefFunction struct{}
+
+func (f *EntityRefFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "entity_ref"
+}
+
+func (f *EntityRefFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build a canonical entity reference",
+		Description: "Builds a canonical entity reference of the form kind:namespace/name, validating each component " +
+			"so that malformed references are reported when planning instead of being rejected by the API.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "kind",
+				Description: "The entity kind, e.g. Component. Must start with a letter and contain only letters and digits.",
+			},
+			function.StringParameter{
+				Name:        "namespace",
+				Description: "The entity namespace. Must be lowercase letters, digits and hyphens. An empty string selects the \"default\" namespace.",
+			},
+			function.StringParameter{
+				Name:        "name",
+				Description: "The entity name. Must be letters, digits, '.', '_' and '-', starting and ending with a letter or digit.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *EntityRefFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var kind, namespace, name string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &kind, &namespace, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	if namespace == "" {
+		namespace = defaultEntityNamespace
+	}
+
+	if !entityKindPattern.MatchString(kind) {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, fmt.Sprintf("Invalid entity kind %q: must start with a letter and contain only letters and digits", kind)))
+	}
+	if !entityNamespacePattern.MatchString(namespace) {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(1, fmt.Sprintf("Invalid entity namespace %q: must be lowercase letters, digits and hyphens", namespace)))
+	}
+	if !entityNamePattern.MatchString(name) {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(2, fmt.Sprintf("Invalid entity name %q: must be letters, digits, '.', '_' and '-', starting and ending with a letter or digit", name)))
+	}
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, kind+":"+namespace+"/"+name))
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "github.com/arctir/go-devgraph/pkg/apis/devgraph/v1"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type environmentContextKey struct{}
+
+// withEnvironment returns a context whose API requests are sent to the given environment instead
+// of the provider's. A null or empty environment leaves the provider's environment in place.
+func withEnvironment(ctx context.Context, environment types.String) context.Context {
+	if environment.IsNull() || environment.IsUnknown() || environment.ValueString() == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, environmentContextKey{}, environment.ValueString())
+}
+
+// environmentFromContext returns the environment set by withEnvironment, if any
+func environmentFromContext(ctx context.Context) (string, bool) {
+	environment, ok := ctx.Value(environmentContextKey{}).(string)
+	return environment, ok
+}
+
+// environmentAttribute is the schema of the optional per-resource environment override
+// shared by all environment-scoped resources
+func environmentAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "The Devgraph environment (organization slug) the resource belongs to. Defaults to the provider's environment. Changing this forces a new resource.",
+		Optional:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// importStateWithEnvironment imports an environment-scoped resource from either "<id>" or
+// "<environment>/<id>", storing the identifier at idPath
+func importStateWithEnvironment(ctx context.Context, idPath path.Path, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	environment, id, diags := parseImportID(req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if environment != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), environment)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, idPath, id)...)
+}
+
+// parseImportID splits an import ID of the form "<id>" or "<environment>/<id>"
+func parseImportID(importID string) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	environment, id, found := strings.Cut(importID, "/")
+	if !found {
+		return "", importID, diags
+	}
+
+	if environment == "" || id == "" {
+		diags.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID in the format <id> or <environment>/<id>, got: %s", importID),
+		)
+	}
+
+	return environment, id, diags
+}
+
+// resolveEnvironmentSlug looks up the slug of the environment with the given display name
+// among the environments the caller has access to
+func resolveEnvironmentSlug(ctx context.Context, client *v1.Client, name string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	res, err := client.GetEnvironments(ctx)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("environment_name"),
+			"Unable to Resolve Devgraph Environment",
+			"Could not list environments: "+err.Error(),
+		)
+		return "", diags
+	}
+
+	environments, ok := res.(*v1.GetEnvironmentsOKApplicationJSON)
+	if !ok {
+		diags.AddError(
+			"Unexpected response type",
+			fmt.Sprintf("Expected *v1.GetEnvironmentsOKApplicationJSON, got: %T", res),
+		)
+		return "", diags
+	}
+
+	var slugs []string
+	for _, environment := range *environments {
+		if environment.Name == name {
+			slugs = append(slugs, environment.Slug)
+		}
+	}
+
+	switch len(slugs) {
+	case 1:
+		return slugs[0], diags
+	case 0:
+		diags.AddAttributeError(
+			path.Root("environment_name"),
+			"Devgraph Environment Not Found",
+			fmt.Sprintf("No environment named %q is accessible with the configured credentials.", name),
+		)
+	default:
+		diags.AddAttributeError(
+			path.Root("environment_name"),
+			"Ambiguous Devgraph Environment Name",
+			fmt.Sprintf("Found %d environments named %q (slugs: %s). Set environment to the slug instead.", len(slugs), name, strings.Join(slugs, ", ")),
+		)
+	}
+
+	return "", diags
+}
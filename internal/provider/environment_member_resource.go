@@ -0,0 +1,330 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "github.com/arctir/go-devgraph/pkg/apis/devgraph/v1"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &EnvironmentMemberResource{}
+	_ resource.ResourceWithConfigure   = &EnvironmentMemberResource{}
+	_ resource.ResourceWithImportState = &EnvironmentMemberResource{}
+)
+
+func NewEnvironmentMemberResource() resource.Resource {
+	return &EnvironmentMemberResource{}
+}
+
+type EnvironmentMemberResource struct {
+	client *v1.Client
+}
+
+type EnvironmentMemberResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	Email         types.String `tfsdk:"email"`
+	Role          types.String `tfsdk:"role"`
+	Status        types.String `tfsdk:"status"`
+	Timeouts      types.Object `tfsdk:"timeouts"`
+}
+
+func (r *EnvironmentMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment_member"
+}
+
+func (r *EnvironmentMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a single member of a Devgraph environment.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the environment user.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment the member belongs to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					uuidValidator(),
+				},
+			},
+			"email": schema.StringAttribute{
+				Description: "The email address of the member.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Description: "The role of the member in the environment (member, admin).",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("member"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("member", "admin"),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "The membership status reported by Devgraph (e.g., whether the invitation is still pending).",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *EnvironmentMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *EnvironmentMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan EnvironmentMemberResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID, err := uuid.Parse(plan.EnvironmentID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Environment ID", err.Error())
+		return
+	}
+
+	createReq := v1.EnvironmentUserCreate{
+		EmailAddress: plan.Email.ValueString(),
+		Role:         v1.NewOptEnvironmentUserCreateRole(v1.EnvironmentUserCreateRole(plan.Role.ValueString())),
+	}
+
+	res, err := r.client.CreateEnvironmentUser(withIdempotencyKey(ctx), &createReq, v1.CreateEnvironmentUserParams{
+		EnvironmentID: environmentID,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating environment member",
+			"Could not create environment member: "+err.Error(),
+		)
+		return
+	}
+
+	switch result := res.(type) {
+	case *v1.EnvironmentUserResponse:
+		plan.ID = types.StringValue(result.ID)
+		plan.Email = types.StringValue(result.EmailAddress)
+		plan.Role = types.StringValue(result.Role)
+		plan.Status = types.StringValue(result.Status)
+	case *v1.CreateEnvironmentUserNotFound:
+		resp.Diagnostics.AddError(
+			"Environment not found",
+			fmt.Sprintf("The environment '%s' was not found.", plan.EnvironmentID.ValueString()),
+		)
+		return
+	default:
+		resp.Diagnostics.AddError(
+			"Unexpected response type",
+			fmt.Sprintf("Expected *v1.EnvironmentUserResponse, got: %T", res),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *EnvironmentMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state EnvironmentMemberResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID, err := uuid.Parse(state.EnvironmentID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Environment ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetEnvironmentUser(ctx, v1.GetEnvironmentUserParams{
+		EnvironmentID: environmentID,
+		UserID:        state.ID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading environment member",
+			"Could not read environment member ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	switch result := res.(type) {
+	case *v1.EnvironmentUserResponse:
+		state.Email = types.StringValue(result.EmailAddress)
+		state.Role = types.StringValue(result.Role)
+		state.Status = types.StringValue(result.Status)
+	case *v1.GetEnvironmentUserNotFound:
+		// Resource was deleted outside Terraform, remove from state
+		resp.State.RemoveResource(ctx)
+		return
+	default:
+		resp.Diagnostics.AddError(
+			"Unexpected response type",
+			fmt.Sprintf("Expected *v1.EnvironmentUserResponse, got: %T", res),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *EnvironmentMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan EnvironmentMemberResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID, err := uuid.Parse(plan.EnvironmentID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Environment ID", err.Error())
+		return
+	}
+
+	// Only the role can change; environment_id and email force replacement
+	updateReq := v1.EnvironmentUserUpdate{
+		Role: v1.EnvironmentUserUpdateRole(plan.Role.ValueString()),
+	}
+
+	res, err := r.client.UpdateEnvironmentUser(ctx, &updateReq, v1.UpdateEnvironmentUserParams{
+		EnvironmentID: environmentID,
+		UserID:        plan.ID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating environment member",
+			"Could not update environment member: "+err.Error(),
+		)
+		return
+	}
+
+	result, ok := res.(*v1.EnvironmentUserResponse)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected response type",
+			fmt.Sprintf("Expected *v1.EnvironmentUserResponse, got: %T", res),
+		)
+		return
+	}
+
+	plan.Email = types.StringValue(result.EmailAddress)
+	plan.Role = types.StringValue(result.Role)
+	plan.Status = types.StringValue(result.Status)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *EnvironmentMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state EnvironmentMemberResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID, err := uuid.Parse(state.EnvironmentID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Environment ID", err.Error())
+		return
+	}
+
+	_, err = r.client.DeleteEnvironmentUser(ctx, v1.DeleteEnvironmentUserParams{
+		EnvironmentID: environmentID,
+		UserID:        state.ID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting environment member",
+			"Could not delete environment member: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *EnvironmentMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Members are scoped to an environment, so the import ID is "<environment_id>/<user_id>"
+	environmentID, userID, found := strings.Cut(req.ID, "/")
+	if !found || environmentID == "" || userID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID in the format <environment_id>/<user_id>, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), environmentID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), userID)...)
+}
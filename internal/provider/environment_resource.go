@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -39,6 +40,7 @@ type EnvironmentResourceModel struct {
 	InvitedUsers         types.List   `tfsdk:"invited_users"`
 	StripeSubscriptionID types.String `tfsdk:"stripe_subscription_id"`
 	InstanceURL          types.String `tfsdk:"instance_url"`
+	Timeouts             types.Object `tfsdk:"timeouts"`
 }
 
 func (r *EnvironmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -47,7 +49,7 @@ func (r *EnvironmentResource) Metadata(ctx context.Context, req resource.Metadat
 
 func (r *EnvironmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages an environment in Devgraph.",
+		Description: "Manages an environment in Devgraph. Environments cannot be deleted through the API, so destroying one fails; remove it from state with `terraform state rm` instead.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The unique identifier of the environment.",
@@ -90,8 +92,14 @@ func (r *EnvironmentResource) Schema(ctx context.Context, req resource.SchemaReq
 			"instance_url": schema.StringAttribute{
 				Description: "The instance URL for this environment.",
 				Required:    true,
+				Validators: []validator.String{
+					httpURLValidator(),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
 
@@ -100,16 +108,16 @@ func (r *EnvironmentResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*v1.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *v1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *EnvironmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -120,6 +128,13 @@ func (r *EnvironmentResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build invited users list
 	var invitedUsers []string
 	if !plan.InvitedUsers.IsNull() {
@@ -138,7 +153,7 @@ func (r *EnvironmentResource) Create(ctx context.Context, req resource.CreateReq
 		InvitedUsers:         invitedUsers,
 	}
 
-	res, err := r.client.CreateEnvironment(ctx, &createReq)
+	res, err := r.client.CreateEnvironment(withIdempotencyKey(ctx), &createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating environment",
@@ -177,6 +192,13 @@ func (r *EnvironmentResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Note: The API spec shows get_environments returns a list, not a single environment
 	// We'll need to filter by ID from the list
 	res, err := r.client.GetEnvironments(ctx)
@@ -225,12 +247,28 @@ func (r *EnvironmentResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *EnvironmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// According to the API spec, there's no update endpoint for environments
-	// This is a placeholder that will return an error if an update is attempted
-	resp.Diagnostics.AddError(
-		"Update not supported",
-		"Environments cannot be updated after creation. Please destroy and recreate the resource.",
-	)
+	var plan, state EnvironmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// According to the API spec, there's no update endpoint for environments,
+	// so only the timeouts, which are never sent to the API, can change
+	if !plan.Name.Equal(state.Name) ||
+		!plan.InvitedUsers.Equal(state.InvitedUsers) ||
+		!plan.StripeSubscriptionID.Equal(state.StripeSubscriptionID) ||
+		!plan.InstanceURL.Equal(state.InstanceURL) {
+		resp.Diagnostics.AddError(
+			"Update not supported",
+			"Environments cannot be updated after creation. Please destroy and recreate the resource.",
+		)
+		return
+	}
+
+	state.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *EnvironmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/arctir/go-devgraph/pkg/apis/devgraph/v1"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &EnvironmentSettingsResource{}
+	_ resource.ResourceWithConfigure   = &EnvironmentSettingsResource{}
+	_ resource.ResourceWithImportState = &EnvironmentSettingsResource{}
+)
+
+func NewEnvironmentSettingsResource() resource.Resource {
+	return &EnvironmentSettingsResource{}
+}
+
+type EnvironmentSettingsResource struct {
+	client *v1.Client
+}
+
+type EnvironmentSettingsResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	EnvironmentID    types.String `tfsdk:"environment_id"`
+	DiscoveryEnabled types.Bool   `tfsdk:"discovery_enabled"`
+	DiscoveryImageID types.String `tfsdk:"discovery_image_id"`
+	Timeouts         types.Object `tfsdk:"timeouts"`
+}
+
+func (r *EnvironmentSettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment_settings"
+}
+
+func (r *EnvironmentSettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the settings of a Devgraph environment. Each environment has exactly one set of settings, so destroying this resource only removes it from Terraform state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the settings, equal to the environment ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				Description: "The ID of the environment to configure.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					uuidValidator(),
+				},
+			},
+			"discovery_enabled": schema.BoolAttribute{
+				Description: "Whether discovery is enabled for the environment.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"discovery_image_id": schema.StringAttribute{
+				Description: "The ID of the discovery image used to run discovery for the environment.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					uuidValidator(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *EnvironmentSettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *EnvironmentSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan EnvironmentSettingsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Settings always exist for an environment, so creating this resource
+	// simply applies the configured values
+	resp.Diagnostics.Append(r.apply(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *EnvironmentSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state EnvironmentSettingsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID, err := uuid.Parse(state.EnvironmentID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Environment ID", err.Error())
+		return
+	}
+
+	res, err := r.client.GetEnvironmentDiscoverySettings(ctx, v1.GetEnvironmentDiscoverySettingsParams{
+		EnvID: environmentID,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading environment settings",
+			"Could not read settings for environment ID "+state.EnvironmentID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	switch result := res.(type) {
+	case *v1.EnvironmentDiscoverySettingsResponse:
+		state.ID = types.StringValue(environmentID.String())
+		setEnvironmentSettingsState(&state, result)
+	case *v1.GetEnvironmentDiscoverySettingsNotFound:
+		// Environment was deleted outside Terraform, remove from state
+		resp.State.RemoveResource(ctx)
+		return
+	default:
+		resp.Diagnostics.AddError(
+			"Unexpected response type",
+			fmt.Sprintf("Expected *v1.EnvironmentDiscoverySettingsResponse, got: %T", res),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *EnvironmentSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan EnvironmentSettingsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *EnvironmentSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Environment settings cannot be deleted, only changed. Removing the
+	// resource leaves the current settings in place.
+}
+
+func (r *EnvironmentSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("environment_id"), req, resp)
+}
+
+// apply sends the configured settings to the API and refreshes the model from the response
+func (r *EnvironmentSettingsResource) apply(ctx context.Context, plan *EnvironmentSettingsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	environmentID, err := uuid.Parse(plan.EnvironmentID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid Environment ID", err.Error())
+		return diags
+	}
+
+	updateReq := v1.EnvironmentDiscoverySettingsUpdate{}
+
+	if !plan.DiscoveryEnabled.IsNull() && !plan.DiscoveryEnabled.IsUnknown() {
+		updateReq.DiscoveryEnabled = v1.NewOptNilBool(plan.DiscoveryEnabled.ValueBool())
+	}
+
+	if !plan.DiscoveryImageID.IsNull() && !plan.DiscoveryImageID.IsUnknown() {
+		imageID, err := uuid.Parse(plan.DiscoveryImageID.ValueString())
+		if err != nil {
+			diags.AddError("Invalid Discovery Image ID", err.Error())
+			return diags
+		}
+		updateReq.DiscoveryImageID = v1.NewOptNilUUID(imageID)
+	}
+
+	res, err := r.client.UpdateEnvironmentDiscoverySettings(ctx, &updateReq, v1.UpdateEnvironmentDiscoverySettingsParams{
+		EnvID: environmentID,
+	})
+	if err != nil {
+		diags.AddError(
+			"Error updating environment settings",
+			"Could not update environment settings: "+err.Error(),
+		)
+		return diags
+	}
+
+	switch result := res.(type) {
+	case *v1.EnvironmentDiscoverySettingsResponse:
+		plan.ID = types.StringValue(environmentID.String())
+		setEnvironmentSettingsState(plan, result)
+	case *v1.UpdateEnvironmentDiscoverySettingsNotFound:
+		diags.AddError(
+			"Environment not found",
+			fmt.Sprintf("The environment '%s' was not found.", plan.EnvironmentID.ValueString()),
+		)
+	default:
+		diags.AddError(
+			"Unexpected response type",
+			fmt.Sprintf("Expected *v1.EnvironmentDiscoverySettingsResponse, got: %T", res),
+		)
+	}
+
+	return diags
+}
+
+func setEnvironmentSettingsState(model *EnvironmentSettingsResourceModel, result *v1.EnvironmentDiscoverySettingsResponse) {
+	model.DiscoveryEnabled = types.BoolValue(result.DiscoveryEnabled)
+	if result.DiscoveryImageID.IsSet() && !result.DiscoveryImageID.IsNull() {
+		model.DiscoveryImageID = types.StringValue(result.DiscoveryImageID.Value.String())
+	} else {
+		model.DiscoveryImageID = types.StringNull()
+	}
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type idempotencyKeyContextKey struct{}
+
+// withIdempotencyKey returns a context for a single create request that carries a new
+// Idempotency-Key header, so that a create retried after a timeout or dropped connection is
+// recognized by the server instead of creating a duplicate. Retries replay the request with the
+// same key; every create call gets a key of its own.
+//
+// The key is random rather than derived from the plan: count and for_each instances, and a
+// resource replaced with the same arguments, plan identically and would share a key, and
+// private state is discarded when a create fails, so it cannot carry a key to the next apply.
+// A create that timed out in one apply and is retried by the next can therefore still produce
+// a duplicate, which has to be imported or deleted by hand.
+func withIdempotencyKey(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, uuid.NewString())
+}
+
+// idempotencyTransport wraps an http.RoundTripper to send the idempotency key set by
+// withIdempotencyKey on POST requests
+type idempotencyTransport struct {
+	base http.RoundTripper
+}
+
+func (t *idempotencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, ok := req.Context().Value(idempotencyKeyContextKey{}).(string)
+	if !ok || req.Method != http.MethodPost {
+		return t.base.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Idempotency-Key", key)
+	return t.base.RoundTrip(req)
+}
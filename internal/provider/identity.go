@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// resourceIdentityModel is the identity of an environment-scoped resource, used by list
+// resources and by import blocks that identify a resource with identity instead of an ID
+type resourceIdentityModel struct {
+	ID          types.String `tfsdk:"id"`
+	Environment types.String `tfsdk:"environment"`
+}
+
+// resourceIdentitySchema returns the identity schema shared by environment-scoped resources
+func resourceIdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "The unique identifier of the resource.",
+				RequiredForImport: true,
+			},
+			"environment": identityschema.StringAttribute{
+				Description:       "The Devgraph environment (organization slug) the resource belongs to, if not the provider's.",
+				OptionalForImport: true,
+			},
+		},
+	}
+}
+
+// setResourceIdentity records the identity of a resource. Identity is nil when Terraform
+// doesn't support resource identity.
+func setResourceIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id types.String, environment types.String) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, resourceIdentityModel{ID: id, Environment: environment})
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// importStateWithLookup imports an environment-scoped resource like importStateWithEnvironment,
+// but also accepts a name in place of the ID, either as "name/<name>" or as a bare identifier
+// that is not a UUID. Names are resolved to an ID with lookup, in the environment given by the
+// import ID or the provider's.
+func importStateWithLookup(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, lookup func(context.Context, string) (string, diag.Diagnostics)) {
+	// Import blocks may identify the resource with its identity instead of an ID
+	if req.ID == "" && req.Identity != nil {
+		var identity resourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), identity.Environment)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ID)...)
+		return
+	}
+
+	environment, identifier, byName, diags := parseLookupImportID(req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := identifier
+	if byName {
+		id, diags = lookup(withEnvironment(ctx, types.StringValue(environment)), identifier)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if environment != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), environment)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// parseLookupImportID splits an import ID of the form "[<environment>/]<id>",
+// "[<environment>/]<name>" or "[<environment>/]name/<name>", reporting whether the identifier
+// is a name. The "name/" prefix forces a lookup by name, e.g. for a name that looks like a UUID.
+func parseLookupImportID(importID string) (string, string, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	parts := strings.Split(importID, "/")
+	switch {
+	case len(parts) == 2 && parts[0] == "name":
+		parts = append([]string{""}, parts...)
+	case len(parts) == 3 && parts[1] == "name" && parts[0] != "":
+	default:
+		environment, identifier, diags := parseImportID(importID)
+		_, err := uuid.Parse(identifier)
+		return environment, identifier, err != nil, diags
+	}
+
+	if parts[2] == "" {
+		diags.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID in the format <id>, <name>, name/<name> or any of these prefixed with <environment>/, got: %s", importID),
+		)
+	}
+
+	return parts[0], parts[2], true, diags
+}
+
+// importMatch is a candidate for an import by name
+type importMatch struct {
+	id   string
+	name string
+}
+
+// matchImportName returns the ID of the only candidate with the given name. kind names the
+// resource in error messages, e.g. "OAuth service".
+func matchImportName(kind string, name string, candidates []importMatch) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var ids []string
+	for _, candidate := range candidates {
+		if candidate.name == name {
+			ids = append(ids, candidate.id)
+		}
+	}
+
+	switch len(ids) {
+	case 1:
+		return ids[0], diags
+	case 0:
+		diags.AddError(
+			"Cannot import "+kind,
+			fmt.Sprintf("No %s named %q was found.", kind, name),
+		)
+	default:
+		diags.AddError(
+			"Cannot import "+kind,
+			fmt.Sprintf("Found %d %ss named %q (IDs: %s). Import by ID instead.", len(ids), kind, name, strings.Join(ids, ", ")),
+		)
+	}
+
+	return "", diags
+}
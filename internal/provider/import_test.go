@@ -0,0 +1,41 @@
+package provider
+
+import "testing"
+
+func TestParseLookupImportID(t *testing.T) {
+	const id = "8f14e45f-ceea-467f-a0e6-9a2d3c1b2e4d"
+
+	tests := []struct {
+		importID    string
+		environment string
+		identifier  string
+		byName      bool
+		err         bool
+	}{
+		{importID: id, identifier: id},
+		{importID: "github", identifier: "github", byName: true},
+		{importID: "name/github", identifier: "github", byName: true},
+		{importID: "name/" + id, identifier: id, byName: true},
+		{importID: "my-org-staging/" + id, environment: "my-org-staging", identifier: id},
+		{importID: "my-org-staging/github", environment: "my-org-staging", identifier: "github", byName: true},
+		{importID: "my-org-staging/name/github", environment: "my-org-staging", identifier: "github", byName: true},
+		{importID: "name/", err: true},
+		{importID: "my-org-staging/name/", err: true},
+		{importID: "/github", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.importID, func(t *testing.T) {
+			environment, identifier, byName, diags := parseLookupImportID(test.importID)
+			if diags.HasError() != test.err {
+				t.Fatalf("got error %t, want %t: %v", diags.HasError(), test.err, diags)
+			}
+			if test.err {
+				return
+			}
+			if environment != test.environment || identifier != test.identifier || byName != test.byName {
+				t.Errorf("got (%q, %q, %t), want (%q, %q, %t)", environment, identifier, byName, test.environment, test.identifier, test.byName)
+			}
+		})
+	}
+}
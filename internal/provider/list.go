@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listConfigModel is the configuration of the list resources, shared by all
+// environment-scoped resources
+type listConfigModel struct {
+	Environment types.String `tfsdk:"environment"`
+}
+
+// listConfigSchema returns the configuration schema shared by the list resources
+func listConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the resources in a Devgraph environment so they can be imported in bulk with `terraform query`.",
+		Attributes: map[string]schema.Attribute{
+			"environment": schema.StringAttribute{
+				Description: "The Devgraph environment (organization slug) to list resources in. Defaults to the provider's environment.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// listItem is a resource found by a list resource. attributes holds the resource attributes
+// known from the list response, which are returned when the full resource is requested.
+type listItem struct {
+	id          string
+	displayName string
+	attributes  map[string]attr.Value
+}
+
+// streamListResults returns the items as list results, up to the requested limit
+func streamListResults(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream, environment types.String, items []listItem) {
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, item := range items {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = item.displayName
+			result.Diagnostics.Append(result.Identity.Set(ctx, resourceIdentityModel{
+				ID:          types.StringValue(item.id),
+				Environment: environment,
+			})...)
+
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("id"), item.id)...)
+				result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("environment"), environment)...)
+				for name, value := range item.attributes {
+					result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root(name), value)...)
+				}
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// sensitiveBodyKeys are JSON keys whose values are never written to the logs
+var sensitiveBodyKeys = []string{
+	"api_key",
+	"access_token",
+	"refresh_token",
+	"token",
+	"client_secret",
+	"password",
+	"secret",
+	"authorization",
+	"headers",
+}
+
+// loggingTransport wraps an http.RoundTripper to log every API request and response with tflog.
+// Summaries are logged at DEBUG and redacted bodies at TRACE, so they show up with TF_LOG or
+// TF_LOG_PROVIDER set accordingly.
+type loggingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			payload, _ := io.ReadAll(body)
+			body.Close()
+			tflog.Trace(ctx, "Devgraph API request body", map[string]interface{}{
+				"http_method":       req.Method,
+				"http_path":         req.URL.Path,
+				"http_request_body": redactBody(payload),
+			})
+		}
+	}
+
+	start := time.Now()
+	res, err := t.base.RoundTrip(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "Devgraph API request failed", fields)
+		return nil, err
+	}
+
+	fields["http_status"] = res.StatusCode
+	if requestID := res.Header.Get("X-Request-Id"); requestID != "" {
+		fields["request_id"] = requestID
+	}
+	tflog.Debug(ctx, "Devgraph API request", fields)
+
+	// Buffer the response so it can be logged and still read by the client
+	payload, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(payload))
+
+	fields["http_response_body"] = redactBody(payload)
+	tflog.Trace(ctx, "Devgraph API response body", fields)
+
+	return res, nil
+}
+
+// redactBody returns a JSON body with the values of sensitive keys masked. Bodies that are
+// not JSON are summarized by their size only.
+func redactBody(payload []byte) string {
+	if len(payload) == 0 {
+		return ""
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return "<non-JSON body, " + http.DetectContentType(payload) + ">"
+	}
+
+	redacted, err := json.Marshal(redactValue(body))
+	if err != nil {
+		return "<unloggable body>"
+	}
+	return string(redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if isSensitiveBodyKey(key) {
+				v[key] = "***"
+				continue
+			}
+			v[key] = redactValue(nested)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = redactValue(nested)
+		}
+	}
+	return value
+}
+
+func isSensitiveBodyKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveBodyKeys {
+		if key == sensitive || strings.HasSuffix(key, "_"+sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -3,10 +3,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	v1 "github.com/arctir/go-devgraph/pkg/apis/devgraph/v1"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -14,21 +18,31 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ resource.Resource                = &MCPEndpointResource{}
-	_ resource.ResourceWithConfigure   = &MCPEndpointResource{}
-	_ resource.ResourceWithImportState = &MCPEndpointResource{}
+	_ resource.Resource                     = &MCPEndpointResource{}
+	_ resource.ResourceWithConfigure        = &MCPEndpointResource{}
+	_ resource.ResourceWithImportState      = &MCPEndpointResource{}
+	_ resource.ResourceWithIdentity         = &MCPEndpointResource{}
+	_ list.ListResourceWithConfigure        = &MCPEndpointResource{}
+	_ resource.ResourceWithModifyPlan       = &MCPEndpointResource{}
+	_ resource.ResourceWithConfigValidators = &MCPEndpointResource{}
 )
 
 func NewMCPEndpointResource() resource.Resource {
 	return &MCPEndpointResource{}
 }
 
+func NewMCPEndpointListResource() list.ListResource {
+	return &MCPEndpointResource{}
+}
+
 type MCPEndpointResource struct {
-	client *v1.Client
+	client   *v1.Client
+	defaults resourceDefaults
 }
 
 type MCPEndpointResourceModel struct {
@@ -42,8 +56,11 @@ type MCPEndpointResourceModel struct {
 	OAuthServiceID    types.String `tfsdk:"oauth_service_id"`
 	Immutable         types.Bool   `tfsdk:"immutable"`
 	Active            types.Bool   `tfsdk:"active"`
-	AllowedTools      types.List   `tfsdk:"allowed_tools"`
-	DeniedTools       types.List   `tfsdk:"denied_tools"`
+	AllowedTools      types.Set    `tfsdk:"allowed_tools"`
+	DeniedTools       types.Set    `tfsdk:"denied_tools"`
+	VerifyConnection  types.Bool   `tfsdk:"verify_connection"`
+	Environment       types.String `tfsdk:"environment"`
+	Timeouts          types.Object `tfsdk:"timeouts"`
 }
 
 func (r *MCPEndpointResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -61,6 +78,7 @@ func (r *MCPEndpointResource) Schema(ctx context.Context, req resource.SchemaReq
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"environment": environmentAttribute(),
 			"name": schema.StringAttribute{
 				Description: "The name of the MCP endpoint.",
 				Required:    true,
@@ -68,6 +86,9 @@ func (r *MCPEndpointResource) Schema(ctx context.Context, req resource.SchemaReq
 			"url": schema.StringAttribute{
 				Description: "The URL of the MCP endpoint.",
 				Required:    true,
+				Validators: []validator.String{
+					httpURLValidator(),
+				},
 			},
 			"description": schema.StringAttribute{
 				Description: "A description of the MCP endpoint.",
@@ -95,48 +116,208 @@ func (r *MCPEndpointResource) Schema(ctx context.Context, req resource.SchemaReq
 			"oauth_service_id": schema.StringAttribute{
 				Description: "The OAuth service ID to use for authentication.",
 				Optional:    true,
+				Validators: []validator.String{
+					uuidValidator(),
+				},
 			},
 			"immutable": schema.BoolAttribute{
-				Description: "Whether this endpoint configuration is immutable.",
+				Description: "Whether this endpoint configuration is immutable. Once applied, plans that change any attribute other than active are rejected.",
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
 			"active": schema.BoolAttribute{
-				Description: "Whether this MCP endpoint is active.",
+				Description: "Whether this MCP endpoint is active. Defaults to the provider's resource_defaults.mcp_endpoint_active, or true.",
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(true),
 			},
-			"allowed_tools": schema.ListAttribute{
-				Description: "List of allowed tool names for this endpoint.",
+			"allowed_tools": schema.SetAttribute{
+				Description: "Set of allowed tool names for this endpoint. Conflicts with denied_tools.",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
-			"denied_tools": schema.ListAttribute{
-				Description: "List of denied tool names for this endpoint.",
+			"denied_tools": schema.SetAttribute{
+				Description: "Set of denied tool names for this endpoint. Conflicts with allowed_tools. " +
+					"Defaults to the provider's resource_defaults.mcp_endpoint_denied_tools, if set and allowed_tools is not.",
 				Optional:    true,
+				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"verify_connection": schema.BoolAttribute{
+				Description: "Whether creating the endpoint checks that Devgraph can connect to it by listing its tools. " +
+					"If the endpoint is unreachable or rejects Devgraph's credentials, it is removed again and the create fails with the error. " +
+					"Has no effect after creation. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}
 }
 
+func (r *MCPEndpointResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resourceIdentitySchema(ctx, req, resp)
+}
+
 func (r *MCPEndpointResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*v1.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *v1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.defaults = data.resourceDefaults
+}
+
+// ConfigValidators rejects configurations that both allow and deny tools, as the server
+// applies only one of the lists
+func (r *MCPEndpointResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(path.MatchRoot("allowed_tools"), path.MatchRoot("denied_tools")),
+	}
+}
+
+func (r *MCPEndpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when destroying
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config MCPEndpointResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Apply the provider-wide defaults to attributes the resource doesn't set
+	if config.Active.IsNull() && !r.defaults.MCPEndpointActive.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("active"), r.defaults.MCPEndpointActive)...)
+	}
+
+	// denied_tools is only computed so that it can take the provider default;
+	// without one it stays null as if it were a plain optional attribute. The default
+	// doesn't apply to endpoints that allow tools, which can't also deny them.
+	if config.DeniedTools.IsNull() {
+		deniedTools := r.defaults.MCPEndpointDeniedTools
+		if deniedTools.IsNull() || !config.AllowedTools.IsNull() {
+			deniedTools = types.SetNull(types.StringType)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("denied_tools"), deniedTools)...)
+	}
+
+	var plan, state MCPEndpointResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(checkMCPEndpointImmutable(plan, state)...)
+	}
+
+	// Only look up the OAuth service when it is newly referenced
+	if !plan.OAuthServiceID.Equal(state.OAuthServiceID) {
+		resp.Diagnostics.Append(r.checkOAuthServiceExists(ctx, plan)...)
+	}
+}
+
+// checkOAuthServiceExists returns an error if the endpoint references an OAuth service that
+// doesn't exist. IDs that aren't known yet belong to services created in the same apply and
+// are not checked.
+func (r *MCPEndpointResource) checkOAuthServiceExists(ctx context.Context, plan MCPEndpointResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Not configured yet, e.g. during validation
+	if r.client == nil || plan.OAuthServiceID.IsNull() || plan.OAuthServiceID.IsUnknown() {
+		return diags
+	}
+
+	// Malformed IDs are reported by the attribute's validator
+	serviceID, err := uuid.Parse(plan.OAuthServiceID.ValueString())
+	if err != nil {
+		return diags
+	}
+
+	ctx = withEnvironment(ctx, plan.Environment)
+	res, err := r.client.GetOAuthService(ctx, v1.GetOAuthServiceParams{
+		ServiceID: serviceID,
+	})
+	if err != nil {
+		diags.AddWarning(
+			"Could not verify OAuth service",
+			"Unable to read OAuth service ID "+plan.OAuthServiceID.ValueString()+
+				", so it will only be checked on apply: "+err.Error(),
+		)
+		return diags
+	}
+
+	if _, ok := res.(*v1.GetOAuthServiceNotFound); ok {
+		diags.AddAttributeError(
+			path.Root("oauth_service_id"),
+			"OAuth service not found",
+			fmt.Sprintf("No OAuth service with ID %s exists in the environment, so the MCP endpoint could not authenticate with it.",
+				plan.OAuthServiceID.ValueString()),
+		)
+	}
+
+	return diags
+}
+
+// checkMCPEndpointImmutable returns an error for each attribute an update would change on an
+// immutable endpoint. Only active may change; values not yet known at plan time are let
+// through as they may turn out to be unchanged.
+func checkMCPEndpointImmutable(plan, state MCPEndpointResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !state.Immutable.ValueBool() {
+		return diags
+	}
+
+	attributes := []struct {
+		name           string
+		planned, prior attr.Value
+	}{
+		{"name", plan.Name, state.Name},
+		{"url", plan.URL, state.URL},
+		{"description", plan.Description, state.Description},
+		{"headers", plan.Headers, state.Headers},
+		{"devgraph_auth", plan.DevgraphAuth, state.DevgraphAuth},
+		{"supports_resources", plan.SupportsResources, state.SupportsResources},
+		{"oauth_service_id", plan.OAuthServiceID, state.OAuthServiceID},
+		{"immutable", plan.Immutable, state.Immutable},
+		{"allowed_tools", plan.AllowedTools, state.AllowedTools},
+		{"denied_tools", plan.DeniedTools, state.DeniedTools},
+	}
+
+	for _, attribute := range attributes {
+		if attribute.planned.IsUnknown() || attribute.planned.Equal(attribute.prior) {
+			continue
+		}
+		diags.AddAttributeError(
+			path.Root(attribute.name),
+			"Cannot change immutable MCP endpoint",
+			fmt.Sprintf("The MCP endpoint %q is immutable, so only active can be changed in place. "+
+				"Revert the change to %s, or replace the endpoint with `terraform apply -replace=<address>`.",
+				state.Name.ValueString(), attribute.name),
+		)
+	}
+
+	return diags
 }
 
 func (r *MCPEndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -147,6 +328,15 @@ func (r *MCPEndpointResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, plan.Environment)
+
 	// Build headers map
 	headers := make(map[string]string)
 	if !plan.Headers.IsNull() {
@@ -157,7 +347,7 @@ func (r *MCPEndpointResource) Create(ctx context.Context, req resource.CreateReq
 		}
 	}
 
-	// Build allowed tools list
+	// Sets hold no duplicates, so the tools are sent deduplicated
 	var allowedTools []string
 	if !plan.AllowedTools.IsNull() {
 		diags = plan.AllowedTools.ElementsAs(ctx, &allowedTools, false)
@@ -167,7 +357,6 @@ func (r *MCPEndpointResource) Create(ctx context.Context, req resource.CreateReq
 		}
 	}
 
-	// Build denied tools list
 	var deniedTools []string
 	if !plan.DeniedTools.IsNull() {
 		diags = plan.DeniedTools.ElementsAs(ctx, &deniedTools, false)
@@ -216,7 +405,7 @@ func (r *MCPEndpointResource) Create(ctx context.Context, req resource.CreateReq
 		createReq.DeniedTools = v1.NewOptNilStringArray(deniedTools)
 	}
 
-	resultInterface, err := r.client.CreateMcpendpoint(ctx, &createReq)
+	resultInterface, err := r.client.CreateMcpendpoint(withIdempotencyKey(ctx), &createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating MCP endpoint",
@@ -269,10 +458,72 @@ func (r *MCPEndpointResource) Create(ctx context.Context, req resource.CreateReq
 		plan.OAuthServiceID = types.StringValue(result.OAuthServiceID.Value.String())
 	}
 
+	if plan.VerifyConnection.ValueBool() {
+		removed, verifyDiags := r.verifyConnection(ctx, result.ID, plan.Name.ValueString())
+		resp.Diagnostics.Append(verifyDiags...)
+		if removed {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.ID, plan.Environment)...)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+// verifyConnection checks that Devgraph can connect to a newly created endpoint by listing its
+// tools through the API. An endpoint that fails the check is deleted so that a dead endpoint
+// isn't left registered; removed reports whether that succeeded. If it didn't, the endpoint
+// is kept in state, tainted by the returned error.
+func (r *MCPEndpointResource) verifyConnection(ctx context.Context, endpointID uuid.UUID, name string) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	res, err := r.client.ListMcpendpointTools(ctx, v1.ListMcpendpointToolsParams{
+		McpendpointID: endpointID,
+	})
+
+	var problem string
+	switch body := res.(type) {
+	case *v1.ListMcpendpointToolsOKApplicationJSON:
+		return false, diags
+	case *v1.HTTPValidationError:
+		messages := make([]string, 0, len(body.Detail))
+		for _, detail := range body.Detail {
+			messages = append(messages, detail.Msg)
+		}
+		problem = strings.Join(messages, "; ")
+	case *v1.ListMcpendpointToolsNotFound:
+		problem = "the endpoint was not found"
+	default:
+		if err != nil {
+			problem = err.Error()
+		} else {
+			problem = fmt.Sprintf("unexpected response type %T", res)
+		}
+	}
+
+	diags.AddAttributeError(
+		path.Root("verify_connection"),
+		"MCP endpoint connection failed",
+		fmt.Sprintf("Devgraph could not connect to the MCP endpoint %q: %s. "+
+			"Check that the URL is reachable from Devgraph and that its authentication is configured correctly.", name, problem),
+	)
+
+	_, err = r.client.DeleteMcpendpoint(ctx, v1.DeleteMcpendpointParams{
+		McpendpointID: endpointID,
+	})
+	if err != nil {
+		diags.AddError(
+			"Error removing unreachable MCP endpoint",
+			"Could not delete the MCP endpoint that failed verification, so it was kept in state to be replaced on the next apply: "+err.Error(),
+		)
+		return false, diags
+	}
+
+	return true, diags
+}
+
 func (r *MCPEndpointResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state MCPEndpointResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -281,6 +532,15 @@ func (r *MCPEndpointResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, state.Environment)
+
 	endpointID, err := uuid.Parse(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid MCP Endpoint ID", err.Error())
@@ -348,6 +608,13 @@ func (r *MCPEndpointResource) Read(ctx context.Context, req resource.ReadRequest
 		state.OAuthServiceID = types.StringValue(result.OAuthServiceID.Value.String())
 	}
 
+	// Only used on create, so imported endpoints take the default
+	if state.VerifyConnection.IsNull() {
+		state.VerifyConnection = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.ID, state.Environment)...)
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -360,6 +627,15 @@ func (r *MCPEndpointResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, plan.Environment)
+
 	endpointID, err := uuid.Parse(plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid MCP Endpoint ID", err.Error())
@@ -465,6 +741,8 @@ func (r *MCPEndpointResource) Update(ctx context.Context, req resource.UpdateReq
 		plan.Description = types.StringValue(result.Description.Value)
 	}
 
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.ID, plan.Environment)...)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -477,6 +755,15 @@ func (r *MCPEndpointResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, state.Environment)
+
 	endpointID, err := uuid.Parse(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid MCP Endpoint ID", err.Error())
@@ -496,7 +783,81 @@ func (r *MCPEndpointResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *MCPEndpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithLookup(ctx, req, resp, r.lookupByName)
+}
+
+// lookupByName returns the ID of the MCP endpoint with the given name
+func (r *MCPEndpointResource) lookupByName(ctx context.Context, name string) (string, diag.Diagnostics) {
+	endpoints, diags := r.listEndpoints(ctx)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	candidates := make([]importMatch, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		candidates = append(candidates, importMatch{id: endpoint.ID.String(), name: endpoint.Name})
+	}
+
+	return matchImportName("MCP endpoint", name, candidates)
+}
+
+func (r *MCPEndpointResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	listConfigSchema(ctx, req, resp)
+}
+
+func (r *MCPEndpointResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config listConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	endpoints, diags := r.listEndpoints(withEnvironment(ctx, config.Environment))
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	items := make([]listItem, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		items = append(items, listItem{
+			id:          endpoint.ID.String(),
+			displayName: endpoint.Name,
+			attributes: map[string]attr.Value{
+				"name":   types.StringValue(endpoint.Name),
+				"url":    types.StringValue(endpoint.URL),
+				"active": types.BoolValue(endpoint.Active.Or(true)),
+			},
+		})
+	}
+
+	streamListResults(ctx, req, stream, config.Environment, items)
+}
+
+// listEndpoints returns all MCP endpoints in the environment
+func (r *MCPEndpointResource) listEndpoints(ctx context.Context) ([]v1.MCPEndpointResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	res, err := r.client.GetMcpendpoints(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error reading MCP endpoints",
+			"Could not list MCP endpoints: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	result, ok := res.(*v1.GetMcpendpointsOKApplicationJSON)
+	if !ok {
+		diags.AddError(
+			"Unexpected response type",
+			fmt.Sprintf("Expected *v1.GetMcpendpointsOKApplicationJSON, got: %T", res),
+		)
+		return nil, diags
+	}
+
+	return *result, diags
 }
 
 // Helper function to convert map[string]types.String to map[string]attr.Value
@@ -3,10 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	v1 "github.com/arctir/go-devgraph/pkg/apis/devgraph/v1"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -21,22 +26,32 @@ var (
 	_ resource.Resource                = &ModelProviderResource{}
 	_ resource.ResourceWithConfigure   = &ModelProviderResource{}
 	_ resource.ResourceWithImportState = &ModelProviderResource{}
+	_ resource.ResourceWithIdentity    = &ModelProviderResource{}
+	_ list.ListResourceWithConfigure   = &ModelProviderResource{}
 )
 
 func NewModelProviderResource() resource.Resource {
 	return &ModelProviderResource{}
 }
 
+func NewModelProviderListResource() list.ListResource {
+	return &ModelProviderResource{}
+}
+
 type ModelProviderResource struct {
 	client *v1.Client
 }
 
 type ModelProviderResourceModel struct {
-	ID      types.String `tfsdk:"id"`
-	Type    types.String `tfsdk:"type"`
-	Name    types.String `tfsdk:"name"`
-	APIKey  types.String `tfsdk:"api_key"`
-	Default types.Bool   `tfsdk:"default"`
+	ID            types.String `tfsdk:"id"`
+	Type          types.String `tfsdk:"type"`
+	Name          types.String `tfsdk:"name"`
+	APIKey        types.String `tfsdk:"api_key"`
+	APIKeyWO      types.String `tfsdk:"api_key_wo"`
+	APIKeyVersion types.Int64  `tfsdk:"api_key_wo_version"`
+	Default       types.Bool   `tfsdk:"default"`
+	Environment   types.String `tfsdk:"environment"`
+	Timeouts      types.Object `tfsdk:"timeouts"`
 }
 
 func (r *ModelProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -54,6 +69,7 @@ func (r *ModelProviderResource) Schema(ctx context.Context, req resource.SchemaR
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"environment": environmentAttribute(),
 			"type": schema.StringAttribute{
 				Description: "The type of model provider (openai, anthropic, xai).",
 				Required:    true,
@@ -66,9 +82,26 @@ func (r *ModelProviderResource) Schema(ctx context.Context, req resource.SchemaR
 				Required:    true,
 			},
 			"api_key": schema.StringAttribute{
-				Description: "The API key for the model provider.",
-				Required:    true,
+				Description: "The API key for the model provider. Exactly one of api_key or api_key_wo must be set.",
+				Optional:    true,
 				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("api_key_wo")),
+				},
+			},
+			"api_key_wo": schema.StringAttribute{
+				Description: "The API key for the model provider, as a write-only value that is never stored in state. " +
+					"Requires Terraform 1.11 or later. The key is only sent when the provider is created or api_key_wo_version changes.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"api_key_wo_version": schema.Int64Attribute{
+				Description: "The version of api_key_wo. Change it to send a rotated key to Devgraph.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("api_key_wo")),
+				},
 			},
 			"default": schema.BoolAttribute{
 				Description: "Whether this is the default model provider.",
@@ -77,24 +110,31 @@ func (r *ModelProviderResource) Schema(ctx context.Context, req resource.SchemaR
 				Default:     booldefault.StaticBool(false),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
 
+func (r *ModelProviderResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resourceIdentitySchema(ctx, req, resp)
+}
+
 func (r *ModelProviderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*v1.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *v1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *ModelProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -105,6 +145,26 @@ func (r *ModelProviderResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, plan.Environment)
+
+	// Write-only values are only available in the configuration
+	apiKey := plan.APIKey
+	writeOnlyAPIKey := plan.APIKey.IsNull()
+	if writeOnlyAPIKey {
+		diags = req.Config.GetAttribute(ctx, path.Root("api_key_wo"), &apiKey)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Create the appropriate provider type based on the type field
 	var createReq v1.ModelProviderCreate
 	providerType := plan.Type.ValueString()
@@ -117,7 +177,7 @@ func (r *ModelProviderResource) Create(ctx context.Context, req resource.CreateR
 				OpenAIModelProviderCreate: v1.OpenAIModelProviderCreate{
 					Type:    "openai",
 					Name:    plan.Name.ValueString(),
-					APIKey:  plan.APIKey.ValueString(),
+					APIKey:  apiKey.ValueString(),
 					Default: v1.NewOptBool(plan.Default.ValueBool()),
 				},
 			},
@@ -129,7 +189,7 @@ func (r *ModelProviderResource) Create(ctx context.Context, req resource.CreateR
 				AnthropicModelProviderCreate: v1.AnthropicModelProviderCreate{
 					Type:    "anthropic",
 					Name:    plan.Name.ValueString(),
-					APIKey:  plan.APIKey.ValueString(),
+					APIKey:  apiKey.ValueString(),
 					Default: v1.NewOptBool(plan.Default.ValueBool()),
 				},
 			},
@@ -141,7 +201,7 @@ func (r *ModelProviderResource) Create(ctx context.Context, req resource.CreateR
 				XAIModelProviderCreate: v1.XAIModelProviderCreate{
 					Type:    "xai",
 					Name:    plan.Name.ValueString(),
-					APIKey:  plan.APIKey.ValueString(),
+					APIKey:  apiKey.ValueString(),
 					Default: v1.NewOptBool(plan.Default.ValueBool()),
 				},
 			},
@@ -154,7 +214,7 @@ func (r *ModelProviderResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	resultInterface, err := r.client.CreateModelprovider(ctx, &createReq)
+	resultInterface, err := r.client.CreateModelprovider(withIdempotencyKey(ctx), &createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating model provider",
@@ -204,6 +264,13 @@ func (r *ModelProviderResource) Create(ctx context.Context, req resource.CreateR
 		}
 	}
 
+	// Keep a write-only key out of state
+	if writeOnlyAPIKey {
+		plan.APIKey = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.ID, plan.Environment)...)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -216,6 +283,15 @@ func (r *ModelProviderResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, state.Environment)
+
 	providerID, err := uuid.Parse(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid Model Provider ID", err.Error())
@@ -250,6 +326,9 @@ func (r *ModelProviderResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	// A key supplied write-only, or not known after import, stays out of state
+	writeOnlyAPIKey := state.APIKey.IsNull()
+
 	// Update state based on provider type
 	switch result.Type {
 	case v1.OpenAIModelProviderResponseModelProviderResponse:
@@ -278,6 +357,12 @@ func (r *ModelProviderResource) Read(ctx context.Context, req resource.ReadReque
 		}
 	}
 
+	if writeOnlyAPIKey {
+		state.APIKey = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.ID, state.Environment)...)
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -293,6 +378,15 @@ func (r *ModelProviderResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, plan.Environment)
+
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -312,8 +406,19 @@ func (r *ModelProviderResource) Update(ctx context.Context, req resource.UpdateR
 	if !plan.Name.Equal(state.Name) {
 		updateReq.Name = v1.NewOptNilString(plan.Name.ValueString())
 	}
-	if !plan.APIKey.Equal(state.APIKey) {
+	writeOnlyAPIKey := plan.APIKey.IsNull()
+	if !writeOnlyAPIKey && !plan.APIKey.Equal(state.APIKey) {
 		updateReq.APIKey = v1.NewOptNilString(plan.APIKey.ValueString())
+	} else if writeOnlyAPIKey && (!plan.APIKeyVersion.Equal(state.APIKeyVersion) || !state.APIKey.IsNull()) {
+		// The write-only key can't be compared with the previous one, so it is
+		// only sent when its version changes or it replaces api_key
+		var apiKey types.String
+		diags = req.Config.GetAttribute(ctx, path.Root("api_key_wo"), &apiKey)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		updateReq.APIKey = v1.NewOptNilString(apiKey.ValueString())
 	}
 	if !plan.Default.Equal(state.Default) {
 		updateReq.Default = v1.NewOptNilBool(plan.Default.ValueBool())
@@ -371,6 +476,13 @@ func (r *ModelProviderResource) Update(ctx context.Context, req resource.UpdateR
 		}
 	}
 
+	// Keep a write-only key out of state
+	if writeOnlyAPIKey {
+		plan.APIKey = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.ID, plan.Environment)...)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -383,6 +495,15 @@ func (r *ModelProviderResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, state.Environment)
+
 	providerID, err := uuid.Parse(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid Model Provider ID", err.Error())
@@ -402,5 +523,112 @@ func (r *ModelProviderResource) Delete(ctx context.Context, req resource.DeleteR
 }
 
 func (r *ModelProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithLookup(ctx, req, resp, r.lookupByName)
+}
+
+// lookupByName returns the ID of the model provider identified by "<type>:<name>"
+func (r *ModelProviderResource) lookupByName(ctx context.Context, identifier string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if providerType, name, found := strings.Cut(identifier, ":"); !found || providerType == "" || name == "" {
+		diags.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID in the format <id> or <type>:<name>, optionally prefixed with <environment>/, got: %s", identifier),
+		)
+		return "", diags
+	}
+
+	providers, diags := r.listProviders(ctx)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	candidates := make([]importMatch, 0, len(providers))
+	for _, provider := range providers {
+		candidates = append(candidates, importMatch{id: provider.id.String(), name: provider.providerType + ":" + provider.name})
+	}
+
+	return matchImportName("model provider", identifier, candidates)
+}
+
+func (r *ModelProviderResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	listConfigSchema(ctx, req, resp)
+}
+
+func (r *ModelProviderResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config listConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	providers, diags := r.listProviders(withEnvironment(ctx, config.Environment))
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	items := make([]listItem, 0, len(providers))
+	for _, provider := range providers {
+		items = append(items, listItem{
+			id:          provider.id.String(),
+			displayName: provider.providerType + ":" + provider.name,
+			attributes: map[string]attr.Value{
+				"type":    types.StringValue(provider.providerType),
+				"name":    types.StringValue(provider.name),
+				"default": types.BoolValue(provider.isDefault),
+			},
+		})
+	}
+
+	streamListResults(ctx, req, stream, config.Environment, items)
+}
+
+// modelProviderSummary holds the fields shared by every model provider type
+type modelProviderSummary struct {
+	id           uuid.UUID
+	providerType string
+	name         string
+	isDefault    bool
+}
+
+// listProviders returns all model providers in the environment
+func (r *ModelProviderResource) listProviders(ctx context.Context) ([]modelProviderSummary, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	res, err := r.client.GetModelproviders(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error reading model providers",
+			"Could not list model providers: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	result, ok := res.(*v1.GetModelprovidersOKApplicationJSON)
+	if !ok {
+		diags.AddError(
+			"Unexpected response type",
+			fmt.Sprintf("Expected *v1.GetModelprovidersOKApplicationJSON, got: %T", res),
+		)
+		return nil, diags
+	}
+
+	providers := make([]modelProviderSummary, 0, len(*result))
+	for _, provider := range *result {
+		switch provider.Type {
+		case v1.OpenAIModelProviderResponseModelProviderResponse:
+			p := provider.OpenAIModelProviderResponse
+			providers = append(providers, modelProviderSummary{id: p.ID, providerType: p.Type, name: p.Name, isDefault: p.Default.Or(false)})
+		case v1.AnthropicModelProviderResponseModelProviderResponse:
+			p := provider.AnthropicModelProviderResponse
+			providers = append(providers, modelProviderSummary{id: p.ID, providerType: p.Type, name: p.Name, isDefault: p.Default.Or(false)})
+		case v1.XAIModelProviderResponseModelProviderResponse:
+			p := provider.XAIModelProviderResponse
+			providers = append(providers, modelProviderSummary{id: p.ID, providerType: p.Type, name: p.Name, isDefault: p.Default.Or(false)})
+		}
+	}
+
+	return providers, diags
 }
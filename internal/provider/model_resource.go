@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -35,6 +36,8 @@ type ModelResourceModel struct {
 	Description types.String `tfsdk:"description"`
 	ProviderID  types.String `tfsdk:"provider_id"`
 	Default     types.Bool   `tfsdk:"default"`
+	Environment types.String `tfsdk:"environment"`
+	Timeouts    types.Object `tfsdk:"timeouts"`
 }
 
 func (r *ModelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,6 +55,7 @@ func (r *ModelResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"environment": environmentAttribute(),
 			"name": schema.StringAttribute{
 				Description: "The name of the model (e.g., 'gpt-4', 'claude-3-opus').",
 				Required:    true,
@@ -63,6 +67,9 @@ func (r *ModelResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"provider_id": schema.StringAttribute{
 				Description: "The ID of the model provider this model belongs to.",
 				Required:    true,
+				Validators: []validator.String{
+					uuidValidator(),
+				},
 			},
 			"default": schema.BoolAttribute{
 				Description: "Whether this is the default model.",
@@ -71,6 +78,9 @@ func (r *ModelResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Default:     booldefault.StaticBool(false),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
 
@@ -79,16 +89,16 @@ func (r *ModelResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*v1.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *v1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *ModelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -99,6 +109,15 @@ func (r *ModelResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, plan.Environment)
+
 	providerID, err := uuid.Parse(plan.ProviderID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid Provider ID", err.Error())
@@ -115,7 +134,7 @@ func (r *ModelResource) Create(ctx context.Context, req resource.CreateRequest,
 		createReq.Description = v1.NewOptNilString(plan.Description.ValueString())
 	}
 
-	resultInterface, err := r.client.CreateModel(ctx, &createReq)
+	resultInterface, err := r.client.CreateModel(withIdempotencyKey(ctx), &createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating model",
@@ -157,6 +176,15 @@ func (r *ModelResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, state.Environment)
+
 	resultInterface, err := r.client.GetModel(ctx, v1.GetModelParams{
 		ModelName: state.Name.ValueString(),
 	})
@@ -211,6 +239,15 @@ func (r *ModelResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, plan.Environment)
+
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -284,6 +321,15 @@ func (r *ModelResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, state.Environment)
+
 	_, err := r.client.DeleteModel(ctx, v1.DeleteModelParams{
 		ModelName: state.Name.ValueString(),
 	})
@@ -298,5 +344,5 @@ func (r *ModelResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 func (r *ModelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// For models, we import by name, not ID
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	importStateWithEnvironment(ctx, path.Root("name"), req, resp)
 }
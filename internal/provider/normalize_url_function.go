@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &NormalizeURLFunction{}
+
+func NewNormalizeURLFunction() function.Function {
+	return &NormalizeURLFunction{}
+}
+
+type NormalizeURLFunction struct{}
+
+func (f *NormalizeURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_url"
+}
+
+func (f *NormalizeURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalize a URL",
+		Description: "Normalizes an absolute URL by lowercasing the scheme and host, removing the default port for http and https, " +
+			"and removing trailing slashes from the path, so that equivalent URLs don't cause spurious diffs.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "url",
+				Description: "The absolute URL to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := normalizeURL(input)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, normalized))
+}
+
+// normalizeURL lowercases the scheme and host of an absolute URL, drops the default port
+// of its scheme and trims trailing slashes from the path
+func normalizeURL(input string) (string, error) {
+	u, err := url.Parse(input)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %q: %w", input, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid URL %q: must be an absolute URL with a scheme and host", input)
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port != "" {
+		host += ":" + port
+	}
+	u.Host = host
+
+	u.Path = strings.TrimRight(u.Path, "/")
+	if u.RawPath != "" {
+		u.RawPath = strings.TrimRight(u.RawPath, "/")
+	}
+
+	return u.String(), nil
+}
@@ -7,7 +7,11 @@ import (
 
 	v1 "github.com/arctir/go-devgraph/pkg/apis/devgraph/v1"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -15,6 +19,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -22,12 +28,18 @@ var (
 	_ resource.Resource                = &OAuthServiceResource{}
 	_ resource.ResourceWithConfigure   = &OAuthServiceResource{}
 	_ resource.ResourceWithImportState = &OAuthServiceResource{}
+	_ resource.ResourceWithIdentity    = &OAuthServiceResource{}
+	_ list.ListResourceWithConfigure   = &OAuthServiceResource{}
 )
 
 func NewOAuthServiceResource() resource.Resource {
 	return &OAuthServiceResource{}
 }
 
+func NewOAuthServiceListResource() list.ListResource {
+	return &OAuthServiceResource{}
+}
+
 type OAuthServiceResource struct {
 	client *v1.Client
 }
@@ -39,6 +51,8 @@ type OAuthServiceResourceModel struct {
 	Description         types.String `tfsdk:"description"`
 	ClientID            types.String `tfsdk:"client_id"`
 	ClientSecret        types.String `tfsdk:"client_secret"`
+	ClientSecretWO      types.String `tfsdk:"client_secret_wo"`
+	ClientSecretVersion types.Int64  `tfsdk:"client_secret_wo_version"`
 	AuthorizationURL    types.String `tfsdk:"authorization_url"`
 	TokenURL            types.String `tfsdk:"token_url"`
 	UserinfoURL         types.String `tfsdk:"userinfo_url"`
@@ -49,6 +63,9 @@ type OAuthServiceResourceModel struct {
 	HomepageURL         types.String `tfsdk:"homepage_url"`
 	CreatedAt           types.String `tfsdk:"created_at"`
 	UpdatedAt           types.String `tfsdk:"updated_at"`
+	Environment         types.String `tfsdk:"environment"`
+	DeletionProtection  types.Bool   `tfsdk:"deletion_protection"`
+	Timeouts            types.Object `tfsdk:"timeouts"`
 }
 
 func (r *OAuthServiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -66,6 +83,7 @@ func (r *OAuthServiceResource) Schema(ctx context.Context, req resource.SchemaRe
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"environment": environmentAttribute(),
 			"name": schema.StringAttribute{
 				Description: "The name of the OAuth service (used as identifier).",
 				Required:    true,
@@ -86,21 +104,47 @@ func (r *OAuthServiceResource) Schema(ctx context.Context, req resource.SchemaRe
 				Required:    true,
 			},
 			"client_secret": schema.StringAttribute{
-				Description: "The OAuth client secret.",
-				Required:    true,
+				Description: "The OAuth client secret. Exactly one of client_secret or client_secret_wo must be set.",
+				Optional:    true,
 				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("client_secret_wo")),
+				},
+			},
+			"client_secret_wo": schema.StringAttribute{
+				Description: "The OAuth client secret, as a write-only value that is never stored in state. " +
+					"Requires Terraform 1.11 or later. The secret is only sent when the service is created or client_secret_wo_version changes.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"client_secret_wo_version": schema.Int64Attribute{
+				Description: "The version of client_secret_wo. Change it to send a rotated secret to Devgraph.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("client_secret_wo")),
+				},
 			},
 			"authorization_url": schema.StringAttribute{
 				Description: "The OAuth authorization endpoint URL.",
 				Required:    true,
+				Validators: []validator.String{
+					httpURLValidator(),
+				},
 			},
 			"token_url": schema.StringAttribute{
 				Description: "The OAuth token endpoint URL.",
 				Required:    true,
+				Validators: []validator.String{
+					httpURLValidator(),
+				},
 			},
 			"userinfo_url": schema.StringAttribute{
 				Description: "The OAuth userinfo endpoint URL.",
 				Optional:    true,
+				Validators: []validator.String{
+					httpURLValidator(),
+				},
 			},
 			"default_scopes": schema.ListAttribute{
 				Description: "Default OAuth scopes to request.",
@@ -122,13 +166,20 @@ func (r *OAuthServiceResource) Schema(ctx context.Context, req resource.SchemaRe
 				Computed:    true,
 				Default:     booldefault.StaticBool(true),
 			},
+			"deletion_protection": deletionProtectionAttribute("OAuth service"),
 			"icon_url": schema.StringAttribute{
 				Description: "URL to the service icon.",
 				Optional:    true,
+				Validators: []validator.String{
+					httpURLValidator(),
+				},
 			},
 			"homepage_url": schema.StringAttribute{
 				Description: "URL to the service homepage.",
 				Optional:    true,
+				Validators: []validator.String{
+					httpURLValidator(),
+				},
 			},
 			"created_at": schema.StringAttribute{
 				Description: "Timestamp when the OAuth service was created.",
@@ -142,24 +193,31 @@ func (r *OAuthServiceResource) Schema(ctx context.Context, req resource.SchemaRe
 				Computed:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
 
+func (r *OAuthServiceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resourceIdentitySchema(ctx, req, resp)
+}
+
 func (r *OAuthServiceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*v1.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *v1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *OAuthServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -170,6 +228,25 @@ func (r *OAuthServiceResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, plan.Environment)
+
+	// Write-only values are only available in the configuration
+	clientSecret := plan.ClientSecret
+	if clientSecret.IsNull() {
+		diags = req.Config.GetAttribute(ctx, path.Root("client_secret_wo"), &clientSecret)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Parse required URLs
 	authURL, err := url.Parse(plan.AuthorizationURL.ValueString())
 	if err != nil {
@@ -198,7 +275,7 @@ func (r *OAuthServiceResource) Create(ctx context.Context, req resource.CreateRe
 		Name:                plan.Name.ValueString(),
 		DisplayName:         plan.DisplayName.ValueString(),
 		ClientID:            plan.ClientID.ValueString(),
-		ClientSecret:        plan.ClientSecret.ValueString(),
+		ClientSecret:        clientSecret.ValueString(),
 		AuthorizationURL:    *authURL,
 		TokenURL:            *tokenURL,
 		SupportedGrantTypes: supportedGrantTypes,
@@ -251,7 +328,7 @@ func (r *OAuthServiceResource) Create(ctx context.Context, req resource.CreateRe
 		createReq.HomepageURL = v1.NewOptNilURI(*homepageURL)
 	}
 
-	resultInterface, err := r.client.CreateOAuthService(ctx, &createReq)
+	resultInterface, err := r.client.CreateOAuthService(withIdempotencyKey(ctx), &createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating OAuth service",
@@ -318,6 +395,8 @@ func (r *OAuthServiceResource) Create(ctx context.Context, req resource.CreateRe
 	}
 	plan.SupportedGrantTypes = types.ListValueMust(types.StringType, grantTypeValues)
 
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.ID, plan.Environment)...)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -330,6 +409,15 @@ func (r *OAuthServiceResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, state.Environment)
+
 	serviceID, err := uuid.Parse(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid OAuth Service ID", err.Error())
@@ -365,7 +453,14 @@ func (r *OAuthServiceResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	// Update state
-	// Keep the state.Name - don't overwrite with API response
+	// Keep the state.Name - don't overwrite with API response, unless it is
+	// unknown because the service was just imported
+	if state.Name.IsNull() {
+		state.Name = types.StringValue(result.Name)
+	}
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(false)
+	}
 	state.DisplayName = types.StringValue(result.DisplayName)
 	state.AuthorizationURL = types.StringValue(result.AuthorizationURL)
 	state.TokenURL = types.StringValue(result.TokenURL)
@@ -411,110 +506,46 @@ func (r *OAuthServiceResource) Read(ctx context.Context, req resource.ReadReques
 	}
 	state.SupportedGrantTypes = types.ListValueMust(types.StringType, grantTypeValues)
 
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, state.ID, state.Environment)...)
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *OAuthServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan OAuthServiceResourceModel
+	var state OAuthServiceResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	serviceID, err := uuid.Parse(plan.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid OAuth Service ID", err.Error())
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Build the update request
-	updateReq := v1.OAuthServiceUpdate{}
-
-	if !plan.DisplayName.IsNull() {
-		updateReq.DisplayName = v1.NewOptNilString(plan.DisplayName.ValueString())
-	}
-
-	if !plan.ClientID.IsNull() {
-		updateReq.ClientID = v1.NewOptNilString(plan.ClientID.ValueString())
-	}
-
-	if !plan.ClientSecret.IsNull() {
-		updateReq.ClientSecret = v1.NewOptNilString(plan.ClientSecret.ValueString())
-	}
-
-	if !plan.AuthorizationURL.IsNull() {
-		authURL, err := url.Parse(plan.AuthorizationURL.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Invalid authorization URL", err.Error())
-			return
-		}
-		updateReq.AuthorizationURL = v1.NewOptNilURI(*authURL)
-	}
-
-	if !plan.TokenURL.IsNull() {
-		tokenURL, err := url.Parse(plan.TokenURL.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Invalid token URL", err.Error())
-			return
-		}
-		updateReq.TokenURL = v1.NewOptNilURI(*tokenURL)
-	}
-
-	if !plan.Description.IsNull() {
-		updateReq.Description = v1.NewOptNilString(plan.Description.ValueString())
-	}
-
-	if !plan.DefaultScopes.IsNull() && !plan.DefaultScopes.IsUnknown() {
-		var defaultScopes []string
-		diags = plan.DefaultScopes.ElementsAs(ctx, &defaultScopes, false)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		updateReq.DefaultScopes = v1.NewOptNilStringArray(defaultScopes)
-	}
-
-	if !plan.SupportedGrantTypes.IsNull() && !plan.SupportedGrantTypes.IsUnknown() {
-		var supportedGrantTypes []string
-		diags = plan.SupportedGrantTypes.ElementsAs(ctx, &supportedGrantTypes, false)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		updateReq.SupportedGrantTypes = v1.NewOptNilStringArray(supportedGrantTypes)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if !plan.UserinfoURL.IsNull() {
-		userInfoURL, err := url.Parse(plan.UserinfoURL.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Invalid userinfo URL", err.Error())
-			return
-		}
-		updateReq.UserinfoURL = v1.NewOptNilURI(*userInfoURL)
-	}
+	ctx = withEnvironment(ctx, plan.Environment)
 
-	if !plan.IsActive.IsNull() {
-		updateReq.IsActive = v1.NewOptNilBool(plan.IsActive.ValueBool())
-	}
-
-	if !plan.IconURL.IsNull() {
-		iconURL, err := url.Parse(plan.IconURL.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Invalid icon URL", err.Error())
-			return
-		}
-		updateReq.IconURL = v1.NewOptNilURI(*iconURL)
+	serviceID, err := uuid.Parse(plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid OAuth Service ID", err.Error())
+		return
 	}
 
-	if !plan.HomepageURL.IsNull() {
-		homepageURL, err := url.Parse(plan.HomepageURL.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Invalid homepage URL", err.Error())
-			return
-		}
-		updateReq.HomepageURL = v1.NewOptNilURI(*homepageURL)
+	updateReq, diags := buildOAuthServiceUpdate(ctx, plan, state, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resultInterface, err := r.client.UpdateOAuthService(ctx, &updateReq, v1.UpdateOAuthServiceParams{
@@ -584,10 +615,114 @@ func (r *OAuthServiceResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 	plan.SupportedGrantTypes = types.ListValueMust(types.StringType, grantTypeValues)
 
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, plan.ID, plan.Environment)...)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+// buildOAuthServiceUpdate builds the update request from the plan. The write-only client
+// secret is read from the configuration.
+func buildOAuthServiceUpdate(ctx context.Context, plan, state OAuthServiceResourceModel, config tfsdk.Config) (v1.OAuthServiceUpdate, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	updateReq := v1.OAuthServiceUpdate{}
+
+	if !plan.DisplayName.IsNull() {
+		updateReq.DisplayName = v1.NewOptNilString(plan.DisplayName.ValueString())
+	}
+
+	if !plan.ClientID.IsNull() {
+		updateReq.ClientID = v1.NewOptNilString(plan.ClientID.ValueString())
+	}
+
+	if !plan.ClientSecret.IsNull() {
+		updateReq.ClientSecret = v1.NewOptNilString(plan.ClientSecret.ValueString())
+	} else if !plan.ClientSecretVersion.Equal(state.ClientSecretVersion) || !state.ClientSecret.IsNull() {
+		// The write-only secret can't be compared with the previous one, so it is
+		// only sent when its version changes or it replaces client_secret
+		var clientSecret types.String
+		diags.Append(config.GetAttribute(ctx, path.Root("client_secret_wo"), &clientSecret)...)
+		if diags.HasError() {
+			return updateReq, diags
+		}
+		updateReq.ClientSecret = v1.NewOptNilString(clientSecret.ValueString())
+	}
+
+	if !plan.AuthorizationURL.IsNull() {
+		authURL, err := url.Parse(plan.AuthorizationURL.ValueString())
+		if err != nil {
+			diags.AddError("Invalid authorization URL", err.Error())
+			return updateReq, diags
+		}
+		updateReq.AuthorizationURL = v1.NewOptNilURI(*authURL)
+	}
+
+	if !plan.TokenURL.IsNull() {
+		tokenURL, err := url.Parse(plan.TokenURL.ValueString())
+		if err != nil {
+			diags.AddError("Invalid token URL", err.Error())
+			return updateReq, diags
+		}
+		updateReq.TokenURL = v1.NewOptNilURI(*tokenURL)
+	}
+
+	if !plan.Description.IsNull() {
+		updateReq.Description = v1.NewOptNilString(plan.Description.ValueString())
+	}
+
+	if !plan.DefaultScopes.IsNull() && !plan.DefaultScopes.IsUnknown() {
+		var defaultScopes []string
+		diags.Append(plan.DefaultScopes.ElementsAs(ctx, &defaultScopes, false)...)
+		if diags.HasError() {
+			return updateReq, diags
+		}
+		updateReq.DefaultScopes = v1.NewOptNilStringArray(defaultScopes)
+	}
+
+	if !plan.SupportedGrantTypes.IsNull() && !plan.SupportedGrantTypes.IsUnknown() {
+		var supportedGrantTypes []string
+		diags.Append(plan.SupportedGrantTypes.ElementsAs(ctx, &supportedGrantTypes, false)...)
+		if diags.HasError() {
+			return updateReq, diags
+		}
+		updateReq.SupportedGrantTypes = v1.NewOptNilStringArray(supportedGrantTypes)
+	}
+
+	if !plan.UserinfoURL.IsNull() {
+		userInfoURL, err := url.Parse(plan.UserinfoURL.ValueString())
+		if err != nil {
+			diags.AddError("Invalid userinfo URL", err.Error())
+			return updateReq, diags
+		}
+		updateReq.UserinfoURL = v1.NewOptNilURI(*userInfoURL)
+	}
+
+	if !plan.IsActive.IsNull() {
+		updateReq.IsActive = v1.NewOptNilBool(plan.IsActive.ValueBool())
+	}
+
+	if !plan.IconURL.IsNull() {
+		iconURL, err := url.Parse(plan.IconURL.ValueString())
+		if err != nil {
+			diags.AddError("Invalid icon URL", err.Error())
+			return updateReq, diags
+		}
+		updateReq.IconURL = v1.NewOptNilURI(*iconURL)
+	}
+
+	if !plan.HomepageURL.IsNull() {
+		homepageURL, err := url.Parse(plan.HomepageURL.ValueString())
+		if err != nil {
+			diags.AddError("Invalid homepage URL", err.Error())
+			return updateReq, diags
+		}
+		updateReq.HomepageURL = v1.NewOptNilURI(*homepageURL)
+	}
+
+	return updateReq, diags
+}
+
 func (r *OAuthServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state OAuthServiceResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -596,6 +731,20 @@ func (r *OAuthServiceResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkDeletionProtection(state.DeletionProtection, "OAuth service", state.Name.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withEnvironment(ctx, state.Environment)
+
 	serviceID, err := uuid.Parse(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid OAuth Service ID", err.Error())
@@ -615,5 +764,83 @@ func (r *OAuthServiceResource) Delete(ctx context.Context, req resource.DeleteRe
 }
 
 func (r *OAuthServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithLookup(ctx, req, resp, r.lookupByName)
+}
+
+// lookupByName returns the ID of the OAuth service with the given name
+func (r *OAuthServiceResource) lookupByName(ctx context.Context, name string) (string, diag.Diagnostics) {
+	services, diags := r.listServices(ctx)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	candidates := make([]importMatch, 0, len(services))
+	for _, service := range services {
+		candidates = append(candidates, importMatch{id: service.ID.String(), name: service.Name})
+	}
+
+	return matchImportName("OAuth service", name, candidates)
+}
+
+func (r *OAuthServiceResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	listConfigSchema(ctx, req, resp)
+}
+
+func (r *OAuthServiceResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config listConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	services, diags := r.listServices(withEnvironment(ctx, config.Environment))
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	items := make([]listItem, 0, len(services))
+	for _, service := range services {
+		items = append(items, listItem{
+			id:          service.ID.String(),
+			displayName: service.DisplayName,
+			attributes: map[string]attr.Value{
+				"name":              types.StringValue(service.Name),
+				"display_name":      types.StringValue(service.DisplayName),
+				"authorization_url": types.StringValue(service.AuthorizationURL),
+				"token_url":         types.StringValue(service.TokenURL),
+				"is_active":         types.BoolValue(service.IsActive),
+			},
+		})
+	}
+
+	streamListResults(ctx, req, stream, config.Environment, items)
+}
+
+// listServices returns all OAuth services in the environment, including inactive ones
+func (r *OAuthServiceResource) listServices(ctx context.Context) ([]v1.OAuthServiceResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	res, err := r.client.ListOAuthServices(ctx, v1.ListOAuthServicesParams{
+		ActiveOnly: v1.NewOptBool(false),
+	})
+	if err != nil {
+		diags.AddError(
+			"Error reading OAuth services",
+			"Could not list OAuth services: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	result, ok := res.(*v1.OAuthServiceListResponse)
+	if !ok {
+		diags.AddError(
+			"Unexpected response type",
+			fmt.Sprintf("Expected *v1.OAuthServiceListResponse, got: %T", res),
+		)
+		return nil, diags
+	}
+
+	return result.Services, diags
 }
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &OIDCEndpointsDataSource{}
+	_ datasource.DataSourceWithConfigure = &OIDCEndpointsDataSource{}
+)
+
+func NewOIDCEndpointsDataSource() datasource.DataSource {
+	return &OIDCEndpointsDataSource{}
+}
+
+type OIDCEndpointsDataSource struct {
+	httpClient *http.Client
+}
+
+type OIDCEndpointsDataSourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	Issuer                types.String `tfsdk:"issuer"`
+	AuthorizationEndpoint types.String `tfsdk:"authorization_endpoint"`
+	TokenEndpoint         types.String `tfsdk:"token_endpoint"`
+	UserinfoEndpoint      types.String `tfsdk:"userinfo_endpoint"`
+	ScopesSupported       types.List   `tfsdk:"scopes_supported"`
+	GrantTypesSupported   types.List   `tfsdk:"grant_types_supported"`
+}
+
+// openIDConfiguration holds the fields of an OpenID Connect discovery document used by this data source
+type openIDConfiguration struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserinfoEndpoint      string   `json:"userinfo_endpoint"`
+	ScopesSupported       []string `json:"scopes_supported"`
+	GrantTypesSupported   []string `json:"grant_types_supported"`
+}
+
+func (d *OIDCEndpointsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oidc_endpoints"
+}
+
+func (d *OIDCEndpointsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the endpoints of an OpenID Connect issuer from its .well-known/openid-configuration document, " +
+			"so that devgraph_oauth_service resources can be configured from a single issuer URL.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The issuer URL.",
+				Computed:    true,
+			},
+			"issuer": schema.StringAttribute{
+				Description: "The issuer URL, e.g. https://accounts.google.com.",
+				Required:    true,
+			},
+			"authorization_endpoint": schema.StringAttribute{
+				Description: "The OAuth authorization endpoint URL.",
+				Computed:    true,
+			},
+			"token_endpoint": schema.StringAttribute{
+				Description: "The OAuth token endpoint URL.",
+				Computed:    true,
+			},
+			"userinfo_endpoint": schema.StringAttribute{
+				Description: "The OpenID Connect userinfo endpoint URL, if the issuer provides one.",
+				Computed:    true,
+			},
+			"scopes_supported": schema.ListAttribute{
+				Description: "The scopes the issuer supports, if it publishes them.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"grant_types_supported": schema.ListAttribute{
+				Description: "The grant types the issuer supports, if it publishes them.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *OIDCEndpointsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.httpClient = data.httpClient
+}
+
+func (d *OIDCEndpointsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state OIDCEndpointsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	issuer := strings.TrimRight(state.Issuer.ValueString(), "/")
+	discoveryURL := issuer + "/.well-known/openid-configuration"
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid issuer URL", err.Error())
+		return
+	}
+	httpReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := d.httpClient.Do(httpReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading OpenID configuration",
+			"Could not read "+discoveryURL+": "+err.Error(),
+		)
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError(
+			"Error reading OpenID configuration",
+			fmt.Sprintf("Could not read %s: unexpected status %s", discoveryURL, httpResp.Status),
+		)
+		return
+	}
+
+	var configuration openIDConfiguration
+	if err := json.NewDecoder(httpResp.Body).Decode(&configuration); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading OpenID configuration",
+			"Could not parse "+discoveryURL+" as JSON: "+err.Error(),
+		)
+		return
+	}
+
+	// The discovery document must be for the requested issuer, otherwise the
+	// endpoints could belong to a different identity provider
+	if strings.TrimRight(configuration.Issuer, "/") != issuer {
+		resp.Diagnostics.AddError(
+			"Issuer mismatch",
+			fmt.Sprintf("The OpenID configuration at %s is for issuer %q, not %q.", discoveryURL, configuration.Issuer, state.Issuer.ValueString()),
+		)
+		return
+	}
+
+	state.ID = state.Issuer
+	state.AuthorizationEndpoint = types.StringValue(configuration.AuthorizationEndpoint)
+	state.TokenEndpoint = types.StringValue(configuration.TokenEndpoint)
+	if configuration.UserinfoEndpoint != "" {
+		state.UserinfoEndpoint = types.StringValue(configuration.UserinfoEndpoint)
+	} else {
+		state.UserinfoEndpoint = types.StringNull()
+	}
+
+	state.ScopesSupported, diags = types.ListValueFrom(ctx, types.StringType, configuration.ScopesSupported)
+	resp.Diagnostics.Append(diags...)
+	state.GrantTypesSupported, diags = types.ListValueFrom(ctx, types.StringType, configuration.GrantTypesSupported)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -1,21 +1,38 @@
 package provider
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"regexp"
+	"strconv"
+	"time"
 
 	v1 "github.com/arctir/go-devgraph/pkg/apis/devgraph/v1"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"golang.org/x/oauth2"
 )
 
 var _ provider.Provider = &DevgraphProvider{}
+var _ provider.ProviderWithEphemeralResources = &DevgraphProvider{}
+var _ provider.ProviderWithFunctions = &DevgraphProvider{}
+var _ provider.ProviderWithListResources = &DevgraphProvider{}
 var _ v1.SecuritySource = &devgraphSecuritySource{}
 
 type DevgraphProvider struct {
@@ -23,34 +40,129 @@ type DevgraphProvider struct {
 }
 
 type DevgraphProviderModel struct {
-	Host        types.String `tfsdk:"host"`
-	AccessToken types.String `tfsdk:"access_token"`
-	Environment types.String `tfsdk:"environment"`
+	Host                      types.String      `tfsdk:"host"`
+	AccessToken               types.String      `tfsdk:"access_token"`
+	APIKey                    types.String      `tfsdk:"api_key"`
+	Environment               types.String      `tfsdk:"environment"`
+	ClientID                  types.String      `tfsdk:"client_id"`
+	ClientSecret              types.String      `tfsdk:"client_secret"`
+	TokenURL                  types.String      `tfsdk:"token_url"`
+	Scopes                    types.List        `tfsdk:"scopes"`
+	CredentialsCommand        types.List        `tfsdk:"credentials_command"`
+	MaxRetries                types.Int64       `tfsdk:"max_retries"`
+	RetryMinBackoff           types.String      `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff           types.String      `tfsdk:"retry_max_backoff"`
+	RequestTimeout            types.String      `tfsdk:"request_timeout"`
+	CreateTimeout             types.String      `tfsdk:"create_timeout"`
+	CACertPEM                 types.String      `tfsdk:"ca_cert_pem"`
+	CACertFile                types.String      `tfsdk:"ca_cert_file"`
+	InsecureSkipVerify        types.Bool        `tfsdk:"insecure_skip_verify"`
+	ProxyURL                  types.String      `tfsdk:"proxy_url"`
+	DefaultHeaders            types.Map         `tfsdk:"default_headers"`
+	UserAgentSuffix           types.String      `tfsdk:"user_agent_suffix"`
+	Profile                   types.String      `tfsdk:"profile"`
+	ConfigFile                types.String      `tfsdk:"config_file"`
+	EnvironmentName           types.String      `tfsdk:"environment_name"`
+	TokenFile                 types.String      `tfsdk:"token_file"`
+	ClientCertPEM             types.String      `tfsdk:"client_cert_pem"`
+	ClientKeyPEM              types.String      `tfsdk:"client_key_pem"`
+	APIBasePath               types.String      `tfsdk:"api_base_path"`
+	APIVersion                types.String      `tfsdk:"api_version"`
+	SkipCredentialsValidation types.Bool        `tfsdk:"skip_credentials_validation"`
+	ResourceDefaults          *resourceDefaults `tfsdk:"resource_defaults"`
+	MaxConcurrentRequests     types.Int64       `tfsdk:"max_concurrent_requests"`
+	CorrelationID             types.String      `tfsdk:"correlation_id"`
+	ChangeSource              types.String      `tfsdk:"change_source"`
 }
 
+// devgraphSecuritySource supplies the bearer token for each API operation from a token source,
+// so refreshed tokens are picked up without recreating the client
 type devgraphSecuritySource struct {
-	token string
+	tokenSource oauth2.TokenSource
 }
 
 func (s *devgraphSecuritySource) OAuth2PasswordBearer(ctx context.Context, operationName v1.OperationName) (v1.OAuth2PasswordBearer, error) {
+	token, err := s.tokenSource.Token()
+	if err != nil {
+		return v1.OAuth2PasswordBearer{}, err
+	}
 	return v1.OAuth2PasswordBearer{
-		Token: s.token,
+		Token: token.AccessToken,
 	}, nil
 }
 
-// environmentTransport wraps an http.RoundTripper to add the Devgraph-Environment header
+// environmentTransport wraps an http.RoundTripper to add the Devgraph-Environment header,
+// preferring an environment set on the request context by a resource
 type environmentTransport struct {
 	base        http.RoundTripper
 	environment string
 }
 
 func (t *environmentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	if t.environment != "" {
-		req.Header.Set("Devgraph-Environment", t.environment)
+	environment := t.environment
+	if override, ok := environmentFromContext(req.Context()); ok {
+		environment = override
+	}
+	if environment != "" {
+		req.Header.Set("Devgraph-Environment", environment)
 	}
 	return t.base.RoundTrip(req)
 }
 
+// tokenTransport wraps an http.RoundTripper to authorize requests with tokens from a token source.
+// When the source can mint new tokens, a request rejected with 401 is retried once with a fresh token.
+type tokenTransport struct {
+	base        http.RoundTripper
+	tokenSource oauth2.TokenSource
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.tokenSource.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	authorized := req.Clone(req.Context())
+	token.SetAuthHeader(authorized)
+
+	res, err := t.base.RoundTrip(authorized)
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+
+	refreshable, ok := t.tokenSource.(*refreshableTokenSource)
+	if !ok {
+		return res, nil
+	}
+
+	// The body has already been consumed, so only retry when it can be replayed
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return res, nil
+	}
+
+	refreshable.invalidate(token)
+	token, err = refreshable.Token()
+	if err != nil {
+		// Surface the original 401 rather than the refresh failure
+		return res, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return res, nil
+		}
+		retry.Body = body
+	}
+	token.SetAuthHeader(retry)
+
+	_, _ = io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+
+	return t.base.RoundTrip(retry)
+}
+
 func (p *DevgraphProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "devgraph"
 	resp.Version = p.version
@@ -64,8 +176,31 @@ func (p *DevgraphProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Description: "Devgraph API host URL. Can also be set via DEVGRAPH_HOST environment variable.",
 				Optional:    true,
 			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Description: "Skip checking that a host and credentials are configured, and defer running credentials_command or reading token_file until the first API request. " +
+					"Lets CI pipelines plan or lint configurations without access to real Devgraph credentials; any API request still fails without them. " +
+					"Can also be set via DEVGRAPH_SKIP_CREDENTIALS_VALIDATION environment variable.",
+				Optional: true,
+			},
+			"api_base_path": schema.StringAttribute{
+				Description: "Path prefix under which the API is mounted on the host, e.g. \"/devgraph\" for installs that serve it at https://example.com/devgraph/api/v1. " +
+					"Can also be set via DEVGRAPH_API_BASE_PATH environment variable.",
+				Optional: true,
+			},
+			"api_version": schema.StringAttribute{
+				Description: "Version of the API to send requests to, e.g. \"v1\" or \"v2beta1\". The version must be compatible with v1, which the provider is built against. Defaults to v1.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]*)?$`), "must be an API version such as v1 or v2beta1"),
+				},
+			},
 			"access_token": schema.StringAttribute{
-				Description: "Devgraph API access token. Can also be set via DEVGRAPH_ACCESS_TOKEN environment variable.",
+				Description: "Devgraph API access token. Can also be set via DEVGRAPH_ACCESS_TOKEN or DEVGRAPH_TOKEN environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"api_key": schema.StringAttribute{
+				Description: "Devgraph API key, for deployments that issue static API keys instead of OAuth access tokens. Ignored when access_token is set. Can also be set via DEVGRAPH_API_KEY environment variable.",
 				Optional:    true,
 				Sensitive:   true,
 			},
@@ -73,6 +208,158 @@ func (p *DevgraphProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Description: "Devgraph environment (organization slug). Can also be set via DEVGRAPH_ENVIRONMENT environment variable.",
 				Optional:    true,
 			},
+			"client_id": schema.StringAttribute{
+				Description: "OAuth2 client ID used to obtain access tokens with the client credentials grant instead of a static access_token. Can also be set via DEVGRAPH_CLIENT_ID environment variable.",
+				Optional:    true,
+			},
+			"client_secret": schema.StringAttribute{
+				Description: "OAuth2 client secret used with client_id. Can also be set via DEVGRAPH_CLIENT_SECRET environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"token_url": schema.StringAttribute{
+				Description: "OAuth2 token endpoint URL used with client_id. Can also be set via DEVGRAPH_TOKEN_URL environment variable.",
+				Optional:    true,
+			},
+			"scopes": schema.ListAttribute{
+				Description: "OAuth2 scopes to request with the client credentials grant.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"credentials_command": schema.ListAttribute{
+				Description: "Command and arguments of an external helper that prints an access token to stdout, either as plain text or as JSON with access_token and an optional RFC 3339 expires_at. " +
+					"The command runs when the provider is configured and again whenever the token expires. Takes precedence over access_token and api_key.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of times an API request is retried after a rate limit, server error or dropped connection. Set to 0 to disable retries. Defaults to 3. Can also be set via DEVGRAPH_MAX_RETRIES environment variable.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of API requests in flight at once, across all resources. Use this to protect smaller Devgraph instances from Terraform's parallelism. Unlimited by default.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"retry_min_backoff": schema.StringAttribute{
+				Description: "Delay before the first retry, as a duration string such as \"500ms\" or \"2s\". The delay doubles with each attempt. Defaults to 1s.",
+				Optional:    true,
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				Description: "Upper bound on the delay between retries, as a duration string such as \"30s\". Defaults to 30s.",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Maximum time a single API request may take, as a duration string such as \"30s\". Set to \"0s\" to disable. Defaults to 30s.",
+				Optional:    true,
+			},
+			"create_timeout": schema.StringAttribute{
+				Description: "Maximum time a single create request may take, as a duration string such as \"2m\". Set to \"0s\" to disable. Defaults to 2m.",
+				Optional:    true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM-encoded CA certificates to trust in addition to the system roots, for installs that use a private CA.",
+				Optional:    true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a file of PEM-encoded CA certificates to trust in addition to the system roots. Can also be set via DEVGRAPH_CA_CERT_FILE environment variable.",
+				Optional:    true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Skip verification of the server's TLS certificate. Only use this for testing, as it makes connections vulnerable to interception.",
+				Optional:    true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				Description: "PEM-encoded client certificate to present for mutual TLS, for deployments where the API gateway requires it. Requires client_key_pem.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_pem")),
+				},
+			},
+			"client_key_pem": schema.StringAttribute{
+				Description: "PEM-encoded private key of client_cert_pem.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_pem")),
+				},
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of an HTTP or HTTPS proxy to send API and token requests through. Hosts listed in NO_PROXY still bypass it. " +
+					"When unset, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used. Can also be set via DEVGRAPH_PROXY_URL environment variable.",
+				Optional: true,
+			},
+			"default_headers": schema.MapAttribute{
+				Description: "Extra HTTP headers to send with every API request, e.g. identity headers required by a zero-trust proxy. " +
+					"Authorization, Devgraph-Environment, User-Agent, X-Correlation-ID and X-Devgraph-Change-Source are managed by the provider and cannot be overridden here.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				Description: "Text appended to the User-Agent header sent with every API request, e.g. to identify the pipeline making the changes. Can also be set via DEVGRAPH_USER_AGENT_SUFFIX environment variable.",
+				Optional:    true,
+			},
+			"token_file": schema.StringAttribute{
+				Description: "Path to a file containing an access token, either as plain text or as JSON with access_token and an optional RFC 3339 expires_at. " +
+					"The file is re-read when the token expires, or every minute when it has no expiry, so tokens rotated by an external agent are picked up. " +
+					"Takes precedence over access_token and api_key. Can also be set via DEVGRAPH_TOKEN_FILE environment variable.",
+				Optional: true,
+			},
+			"correlation_id": schema.StringAttribute{
+				Description: "Correlation ID sent in the X-Correlation-ID header of every API request, so server-side logs can be grouped by Terraform run. " +
+					"Defaults to a random ID generated each time the provider is configured. Can also be set via DEVGRAPH_CORRELATION_ID environment variable.",
+				Optional: true,
+			},
+			"change_source": schema.StringAttribute{
+				Description: "Description of what is making the changes, sent in the X-Devgraph-Change-Source header of every API request for audit logs. " +
+					"Defaults to the workspace and run detected from Terraform Cloud, Atlantis or GitHub Actions environment variables. Can also be set via DEVGRAPH_CHANGE_SOURCE environment variable.",
+				Optional: true,
+			},
+			"profile": schema.StringAttribute{
+				Description: "Name of the profile in the shared config file to read host, token and environment from. Values set in the provider block or environment variables take precedence. " +
+					"Defaults to \"default\". Can also be set via DEVGRAPH_PROFILE environment variable.",
+				Optional: true,
+			},
+			"config_file": schema.StringAttribute{
+				Description: "Path to the shared config file. Defaults to ~/.devgraph/config. Can also be set via DEVGRAPH_CONFIG_FILE environment variable.",
+				Optional:    true,
+			},
+			"environment_name": schema.StringAttribute{
+				Description: "Display name of the Devgraph environment to use. The provider looks up the matching environment when it is configured and uses its slug, " +
+					"so configurations don't need tenant-specific slugs. Conflicts with environment. Can also be set via DEVGRAPH_ENVIRONMENT_NAME environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("environment")),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"resource_defaults": schema.SingleNestedBlock{
+				Description: "Defaults for resource attributes, applied to every resource that does not set the attribute itself.",
+				Attributes: map[string]schema.Attribute{
+					"discovery_interval": schema.Int64Attribute{
+						Description: "Default interval, in seconds, of devgraph_discovery_provider resources.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(60),
+						},
+					},
+					"mcp_endpoint_active": schema.BoolAttribute{
+						Description: "Default value of active for devgraph_mcp_endpoint resources, e.g. false to create new endpoints disabled.",
+						Optional:    true,
+					},
+					"mcp_endpoint_denied_tools": schema.SetAttribute{
+						Description: "Default denied_tools of devgraph_mcp_endpoint resources that set neither allowed_tools nor denied_tools.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
 		},
 	}
 }
@@ -86,68 +373,205 @@ func (p *DevgraphProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	// Check environment variables if not set in config
-	host := os.Getenv("DEVGRAPH_HOST")
-	if !config.Host.IsNull() {
-		host = config.Host.ValueString()
+	// Fall back to environment variables for anything not set in config
+	host := stringValueOrEnv(config.Host, "DEVGRAPH_HOST")
+	accessToken := cmp.Or(stringValueOrEnv(config.AccessToken, "DEVGRAPH_ACCESS_TOKEN"), os.Getenv("DEVGRAPH_TOKEN"))
+	apiKey := stringValueOrEnv(config.APIKey, "DEVGRAPH_API_KEY")
+	environment := stringValueOrEnv(config.Environment, "DEVGRAPH_ENVIRONMENT")
+	clientID := stringValueOrEnv(config.ClientID, "DEVGRAPH_CLIENT_ID")
+	clientSecret := stringValueOrEnv(config.ClientSecret, "DEVGRAPH_CLIENT_SECRET")
+	tokenURL := stringValueOrEnv(config.TokenURL, "DEVGRAPH_TOKEN_URL")
+	tokenFile := stringValueOrEnv(config.TokenFile, "DEVGRAPH_TOKEN_FILE")
+	apiBasePath := stringValueOrEnv(config.APIBasePath, "DEVGRAPH_API_BASE_PATH")
+	skipCredentialsValidation := config.SkipCredentialsValidation.ValueBool()
+	if config.SkipCredentialsValidation.IsNull() {
+		skipCredentialsValidation, _ = strconv.ParseBool(os.Getenv("DEVGRAPH_SKIP_CREDENTIALS_VALIDATION"))
+	}
+
+	// Fill anything still missing from the shared config file
+	profile := stringValueOrEnv(config.Profile, "DEVGRAPH_PROFILE")
+	configFile := stringValueOrEnv(config.ConfigFile, "DEVGRAPH_CONFIG_FILE")
+	settings, err := loadConfigProfile(
+		cmp.Or(configFile, defaultConfigFilePath()),
+		cmp.Or(profile, defaultProfile),
+		profile != "" || configFile != "",
+	)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unable to Load Devgraph Config File",
+			err.Error(),
+		)
+		return
+	}
+	host = cmp.Or(host, settings.Host)
+	accessToken = cmp.Or(accessToken, settings.Token)
+	environmentName := stringValueOrEnv(config.EnvironmentName, "DEVGRAPH_ENVIRONMENT_NAME")
+	if environmentName == "" {
+		environment = cmp.Or(environment, settings.Environment)
+	}
+
+	var scopes []string
+	if !config.Scopes.IsNull() {
+		resp.Diagnostics.Append(config.Scopes.ElementsAs(ctx, &scopes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	accessToken := os.Getenv("DEVGRAPH_ACCESS_TOKEN")
-	if !config.AccessToken.IsNull() {
-		accessToken = config.AccessToken.ValueString()
+	var credentialsCommand []string
+	if !config.CredentialsCommand.IsNull() {
+		resp.Diagnostics.Append(config.CredentialsCommand.ElementsAs(ctx, &credentialsCommand, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	environment := os.Getenv("DEVGRAPH_ENVIRONMENT")
-	if !config.Environment.IsNull() {
-		environment = config.Environment.ValueString()
+	maxRetries := parseMaxRetries(config.MaxRetries, &resp.Diagnostics)
+	retryMinBackoff := parseDurationAttribute(config.RetryMinBackoff, path.Root("retry_min_backoff"), defaultRetryMinBackoff, &resp.Diagnostics)
+	retryMaxBackoff := parseDurationAttribute(config.RetryMaxBackoff, path.Root("retry_max_backoff"), defaultRetryMaxBackoff, &resp.Diagnostics)
+	requestTimeout := parseDurationAttribute(config.RequestTimeout, path.Root("request_timeout"), defaultRequestTimeout, &resp.Diagnostics)
+	createTimeout := parseDurationAttribute(config.CreateTimeout, path.Root("create_timeout"), defaultCreateTimeout, &resp.Diagnostics)
+
+	caCertFile := stringValueOrEnv(config.CACertFile, "DEVGRAPH_CA_CERT_FILE")
+	proxyURL := stringValueOrEnv(config.ProxyURL, "DEVGRAPH_PROXY_URL")
+	userAgentSuffix := stringValueOrEnv(config.UserAgentSuffix, "DEVGRAPH_USER_AGENT_SUFFIX")
+
+	defaultHeaders := map[string]string{}
+	if !config.DefaultHeaders.IsNull() {
+		resp.Diagnostics.Append(config.DefaultHeaders.ElementsAs(ctx, &defaultHeaders, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	// Validate required fields
-	if host == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("host"),
-			"Missing Devgraph API Host",
-			"The provider cannot create the Devgraph API client as there is a missing or empty value for the host. "+
-				"Set the host value in the configuration or use the DEVGRAPH_HOST environment variable. ",
-		)
+	credentials := credentialsConfig{
+		accessToken:  accessToken,
+		apiKey:       apiKey,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		tokenURL:     tokenURL,
+		scopes:       scopes,
+		command:      credentialsCommand,
+		tokenFile:    tokenFile,
+		lazy:         skipCredentialsValidation,
 	}
 
-	if accessToken == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("access_token"),
-			"Missing Devgraph Access Token",
-			"The provider cannot create the Devgraph API client as there is a missing or empty value for the access token. "+
-				"Set the access_token value in the configuration or use the DEVGRAPH_ACCESS_TOKEN environment variable. ",
-		)
+	// Validate required fields, unless the configuration is only being planned
+	// or linted without access to real credentials
+	if !skipCredentialsValidation {
+		if host == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("host"),
+				"Missing Devgraph API Host",
+				"The provider cannot create the Devgraph API client as there is a missing or empty value for the host. "+
+					"Set the host value in the configuration, use the DEVGRAPH_HOST environment variable or add it to a profile in the shared config file. ",
+			)
+		}
+
+		resp.Diagnostics.Append(credentials.validate()...)
 	}
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Create OAuth2 token
-	token := &oauth2.Token{
-		AccessToken: accessToken,
-		TokenType:   "Bearer",
+	baseTransport, diags := newBaseTransport(tlsSettings{
+		caCertPEM:          config.CACertPEM.ValueString(),
+		caCertFile:         caCertFile,
+		insecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+		clientCertPEM:      config.ClientCertPEM.ValueString(),
+		clientKeyPEM:       config.ClientKeyPEM.ValueString(),
+	}, proxyURL)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Create OAuth2 HTTP client
-	oauthConfig := &oauth2.Config{}
-	httpClient := oauthConfig.Client(ctx, token)
+	// Token requests go through the same TLS and proxy settings as API requests
+	tokenSource, diags := newTokenSource(credentials, &http.Client{Transport: baseTransport})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Wrap the HTTP client's transport to add Devgraph-Environment header
-	if environment != "" {
-		httpClient.Transport = &environmentTransport{
-			base:        httpClient.Transport,
-			environment: environment,
+	// Create HTTP client that authenticates every request and refreshes
+	// rejected tokens
+	httpClient := &http.Client{
+		Transport: &tokenTransport{
+			base:        &loggingTransport{base: baseTransport},
+			tokenSource: tokenSource,
+		},
+	}
+
+	// Bound each attempt so hung connections fail instead of stalling the run
+	httpClient.Transport = &timeoutTransport{
+		base:          httpClient.Transport,
+		timeout:       requestTimeout,
+		createTimeout: createTimeout,
+	}
+
+	// Limit concurrency per attempt, so that waiting between retries
+	// doesn't hold up other requests
+	if maxConcurrentRequests := config.MaxConcurrentRequests.ValueInt64(); maxConcurrentRequests > 0 {
+		httpClient.Transport = newConcurrencyTransport(httpClient.Transport, int(maxConcurrentRequests))
+	}
+
+	// Retry transient failures before they reach the resources
+	if maxRetries > 0 {
+		httpClient.Transport = &retryTransport{
+			base:       httpClient.Transport,
+			maxRetries: int(maxRetries),
+			minBackoff: retryMinBackoff,
+			maxBackoff: retryMaxBackoff,
 		}
 	}
 
+	// Let the server deduplicate creates that are retried after a timeout
+	httpClient.Transport = &idempotencyTransport{base: httpClient.Transport}
+
+	// Wrap the HTTP client's transport to add Devgraph-Environment header.
+	// Resources may override the environment, so this applies even when the
+	// provider has none configured.
+	envTransport := &environmentTransport{
+		base:        httpClient.Transport,
+		environment: environment,
+	}
+	httpClient.Transport = envTransport
+
+	// Default headers are added first so the environment and token
+	// transports take precedence over them
+	defaultHeaders["User-Agent"] = p.userAgent(req.TerraformVersion, userAgentSuffix)
+	defaultHeaders["X-Correlation-ID"] = cmp.Or(stringValueOrEnv(config.CorrelationID, "DEVGRAPH_CORRELATION_ID"), uuid.New().String())
+	if changeSource := cmp.Or(stringValueOrEnv(config.ChangeSource, "DEVGRAPH_CHANGE_SOURCE"), detectChangeSource()); changeSource != "" {
+		defaultHeaders["X-Devgraph-Change-Source"] = changeSource
+	}
+	httpClient.Transport = &headersTransport{
+		base:    httpClient.Transport,
+		headers: defaultHeaders,
+	}
+
+	serverURL, err := apiServerURL(host, apiBasePath)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host"),
+			"Invalid Devgraph API Host",
+			"Could not parse the host URL: "+err.Error(),
+		)
+		return
+	}
+
+	// The generated client only builds v1 paths, so other versions are
+	// selected by rewriting requests on their way out
+	if apiVersion := config.APIVersion.ValueString(); apiVersion != "" && apiVersion != "v1" {
+		httpClient.Transport = newAPIVersionTransport(httpClient.Transport, serverURL, apiVersion)
+	}
+
 	// Create security source
-	securitySource := &devgraphSecuritySource{token: accessToken}
+	securitySource := &devgraphSecuritySource{tokenSource: tokenSource}
 
 	// Create Devgraph API client
-	client, err := v1.NewClient(host, securitySource, v1.WithClient(httpClient))
+	client, err := v1.NewClient(serverURL.String(), securitySource, v1.WithClient(httpClient))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Devgraph API Client",
@@ -158,8 +582,41 @@ func (p *DevgraphProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	// Environments are listed per user, so the lookup itself needs no environment
+	if environmentName != "" {
+		slug, diags := resolveEnvironmentSlug(ctx, client, environmentName)
+		if skipCredentialsValidation && diags.HasError() {
+			// Without credentials the lookup cannot succeed, but planning
+			// resources that need no API calls should still work
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("environment_name"),
+				"Devgraph Environment Not Resolved",
+				"The environment name could not be resolved and skip_credentials_validation is set, so no environment will be sent with API requests.",
+			)
+		} else {
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			envTransport.environment = slug
+		}
+	}
+
+	data := &providerData{
+		client: client,
+		httpClient: &http.Client{
+			Transport: &loggingTransport{base: baseTransport},
+			Timeout:   requestTimeout,
+		},
+	}
+	if config.ResourceDefaults != nil {
+		data.resourceDefaults = *config.ResourceDefaults
+	}
+
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.EphemeralResourceData = data
+	resp.ListResourceData = data
 }
 
 func (p *DevgraphProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -171,11 +628,102 @@ func (p *DevgraphProvider) Resources(ctx context.Context) []func() resource.Reso
 		NewOAuthServiceResource,
 		NewDiscoveryProviderResource,
 		NewChatSuggestionResource,
+		NewChatSuggestionSetResource,
+		NewEnvironmentMemberResource,
+		NewEnvironmentSettingsResource,
 	}
 }
 
 func (p *DevgraphProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewOIDCEndpointsDataSource,
+	}
+}
+
+func (p *DevgraphProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAPITokenEphemeralResource,
+	}
+}
+
+func (p *DevgraphProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewMCPEndpointListResource,
+		NewModelProviderListResource,
+		NewOAuthServiceListResource,
+		NewDiscoveryProviderListResource,
+	}
+}
+
+func (p *DevgraphProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewEntityRefFunction,
+		NewNormalizeURLFunction,
+	}
+}
+
+// userAgent identifies the provider and Terraform versions making a request, e.g.
+// "terraform-provider-devgraph/1.2.0 terraform/1.9.5 (+https://www.terraform.io)"
+func (p *DevgraphProvider) userAgent(terraformVersion string, suffix string) string {
+	userAgent := "terraform-provider-devgraph/" + p.version
+	if terraformVersion != "" {
+		userAgent += " terraform/" + terraformVersion + " (+https://www.terraform.io)"
+	}
+	if suffix != "" {
+		userAgent += " " + suffix
+	}
+	return userAgent
+}
+
+// stringValueOrEnv returns the configured value, or the named environment variable when it is unset
+func stringValueOrEnv(value types.String, name string) string {
+	if value.IsNull() || value.IsUnknown() {
+		return os.Getenv(name)
+	}
+	return value.ValueString()
+}
+
+// parseMaxRetries returns the configured retry limit, falling back to DEVGRAPH_MAX_RETRIES and then the default
+func parseMaxRetries(value types.Int64, diags *diag.Diagnostics) int64 {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueInt64()
+	}
+
+	env := os.Getenv("DEVGRAPH_MAX_RETRIES")
+	if env == "" {
+		return defaultMaxRetries
+	}
+
+	maxRetries, err := strconv.ParseInt(env, 10, 64)
+	if err != nil || maxRetries < 0 {
+		diags.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Devgraph Max Retries",
+			"The DEVGRAPH_MAX_RETRIES environment variable must be a non-negative integer, got: "+env,
+		)
+		return defaultMaxRetries
+	}
+
+	return maxRetries
+}
+
+// parseDurationAttribute parses an optional duration string attribute, returning fallback when it is unset
+func parseDurationAttribute(value types.String, attributePath path.Path, fallback time.Duration, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil || duration < 0 {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Duration",
+			fmt.Sprintf("Expected a non-negative duration such as \"1s\" or \"500ms\", got: %q", value.ValueString()),
+		)
+		return fallback
+	}
+
+	return duration
 }
 
 func New(version string) func() provider.Provider {
@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"net/http"
+
+	v1 "github.com/arctir/go-devgraph/pkg/apis/devgraph/v1"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// providerData is shared with resources and data sources when the provider is configured
+type providerData struct {
+	client           *v1.Client
+	resourceDefaults resourceDefaults
+
+	// httpClient makes unauthenticated requests to services other than the Devgraph
+	// API, using the provider's TLS, proxy and timeout settings
+	httpClient *http.Client
+}
+
+// resourceDefaults holds provider-wide defaults for resource attributes that are not set in
+// the resource configuration
+type resourceDefaults struct {
+	DiscoveryInterval      types.Int64 `tfsdk:"discovery_interval"`
+	MCPEndpointActive      types.Bool  `tfsdk:"mcp_endpoint_active"`
+	MCPEndpointDeniedTools types.Set   `tfsdk:"mcp_endpoint_denied_tools"`
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// durationPattern matches the duration strings accepted by time.ParseDuration
+var durationPattern = regexp.MustCompile(`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`)
+
+// timeoutsBlock is the schema of the timeouts block shared by all resources
+func timeoutsBlock() schema.SingleNestedBlock {
+	attribute := func(operation string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Description: fmt.Sprintf("Maximum time the %s may take, including retries, as a duration string such as \"10m\". "+
+				"Replaces the provider's request_timeout and create_timeout for its requests.", operation),
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(durationPattern, "must be a duration such as \"30s\" or \"10m\""),
+			},
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		Description: "Timeouts for the resource's operations. Operations without a timeout are only bounded by the provider's per-request timeouts.",
+		Attributes: map[string]schema.Attribute{
+			"create": attribute("create"),
+			"read":   attribute("read"),
+			"update": attribute("update"),
+			"delete": attribute("delete"),
+		},
+	}
+}
+
+type operationTimeoutContextKey struct{}
+
+// withOperationTimeout returns a context that expires after the timeout configured for the
+// operation ("create", "read", "update" or "delete") in the resource's timeouts block. Without
+// one, the context is returned unchanged and the provider's per-request timeouts apply.
+func withOperationTimeout(ctx context.Context, timeouts types.Object, operation string) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if timeouts.IsNull() || timeouts.IsUnknown() {
+		return ctx, func() {}, diags
+	}
+
+	value, ok := timeouts.Attributes()[operation].(types.String)
+	if !ok || value.IsNull() || value.IsUnknown() {
+		return ctx, func() {}, diags
+	}
+
+	timeout := parseDurationAttribute(value, path.Root("timeouts").AtName(operation), 0, &diags)
+	if diags.HasError() || timeout == 0 {
+		return ctx, func() {}, diags
+	}
+
+	ctx = context.WithValue(ctx, operationTimeoutContextKey{}, true)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, diags
+}
+
+// hasOperationTimeout reports whether the context is bounded by a resource timeout set by
+// withOperationTimeout
+func hasOperationTimeout(ctx context.Context) bool {
+	_, ok := ctx.Value(operationTimeoutContextKey{}).(bool)
+	return ok
+}
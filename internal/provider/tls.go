@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// tlsSettings holds the provider's TLS attributes
+type tlsSettings struct {
+	caCertPEM          string
+	caCertFile         string
+	insecureSkipVerify bool
+	clientCertPEM      string
+	clientKeyPEM       string
+}
+
+// buildTLSConfig returns the TLS settings for API connections, trusting the given
+// CA certificates on top of the system roots and presenting the client certificate, if any
+func buildTLSConfig(settings tlsSettings) (*tls.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: settings.insecureSkipVerify,
+	}
+
+	if settings.clientCertPEM != "" || settings.clientKeyPEM != "" {
+		certificate, err := tls.X509KeyPair([]byte(settings.clientCertPEM), []byte(settings.clientKeyPEM))
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("client_cert_pem"),
+				"Invalid Client Certificate",
+				"Could not load the client certificate and key: "+err.Error(),
+			)
+			return nil, diags
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	caCertPEM, caCertFile := settings.caCertPEM, settings.caCertFile
+	if caCertPEM == "" && caCertFile == "" {
+		return tlsConfig, diags
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil || rootCAs == nil {
+		rootCAs = x509.NewCertPool()
+	}
+
+	if caCertPEM != "" && !rootCAs.AppendCertsFromPEM([]byte(caCertPEM)) {
+		diags.AddAttributeError(
+			path.Root("ca_cert_pem"),
+			"Invalid CA Certificate",
+			"No PEM-encoded certificates could be parsed from ca_cert_pem.",
+		)
+	}
+
+	if caCertFile != "" {
+		pem, err := os.ReadFile(caCertFile)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Unable to Read CA Certificate File",
+				"Could not read "+caCertFile+": "+err.Error(),
+			)
+		} else if !rootCAs.AppendCertsFromPEM(pem) {
+			diags.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Invalid CA Certificate",
+				"No PEM-encoded certificates could be parsed from "+caCertFile+".",
+			)
+		}
+	}
+
+	tlsConfig.RootCAs = rootCAs
+
+	return tlsConfig, diags
+}
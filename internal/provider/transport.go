@@ -0,0 +1,321 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	defaultMaxRetries      = 3
+	defaultRetryMinBackoff = 1 * time.Second
+	defaultRetryMaxBackoff = 30 * time.Second
+	defaultRequestTimeout  = 30 * time.Second
+	defaultCreateTimeout   = 2 * time.Minute
+)
+
+// newBaseTransport returns the transport that carries all API and token requests, configured
+// with the provider's TLS and proxy settings
+func newBaseTransport(tlsSettings tlsSettings, proxyURL string) (*http.Transport, diag.Diagnostics) {
+	tlsConfig, diags := buildTLSConfig(tlsSettings)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	// The cloned default transport already honors the proxy environment variables
+	if proxyURL == "" {
+		return transport, diags
+	}
+
+	if _, err := url.Parse(proxyURL); err != nil {
+		diags.AddAttributeError(
+			path.Root("proxy_url"),
+			"Invalid Proxy URL",
+			"Could not parse proxy_url: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	proxyConfig := httpproxy.FromEnvironment()
+	proxyConfig.HTTPProxy = proxyURL
+	proxyConfig.HTTPSProxy = proxyURL
+	proxyFunc := proxyConfig.ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+
+	return transport, diags
+}
+
+// apiServerURL returns the server URL for the API client, mounting the API under basePath
+// on installs that don't serve it from the root of the host
+func apiServerURL(host string, basePath string) (*url.URL, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, err
+	}
+
+	basePath = strings.Trim(basePath, "/")
+	if basePath != "" {
+		u.Path = strings.TrimRight(u.Path, "/") + "/" + basePath
+	}
+
+	return u, nil
+}
+
+// apiVersionTransport wraps an http.RoundTripper to send requests to another version of the API.
+// The generated client always builds /api/v1 paths, so the version segment is rewritten.
+type apiVersionTransport struct {
+	base    http.RoundTripper
+	from    string
+	to      string
+	version string
+}
+
+func newAPIVersionTransport(base http.RoundTripper, serverURL *url.URL, version string) *apiVersionTransport {
+	prefix := strings.TrimRight(serverURL.Path, "/") + "/api/"
+	return &apiVersionTransport{
+		base:    base,
+		from:    prefix + "v1/",
+		to:      prefix + version + "/",
+		version: version,
+	}
+}
+
+func (t *apiVersionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !strings.HasPrefix(req.URL.Path, t.from) {
+		return t.base.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.URL.Path = t.to + strings.TrimPrefix(req.URL.Path, t.from)
+	if req.URL.RawPath != "" {
+		req.URL.RawPath = strings.Replace(req.URL.RawPath, "/api/v1/", "/api/"+t.version+"/", 1)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// retryTransport wraps an http.RoundTripper to retry requests that failed with a
+// transient error, waiting with exponential backoff between attempts or for as long
+// as the server asks via Retry-After
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		res, err := t.base.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !isRetryable(res, err) || !canReplay(req) {
+			return res, err
+		}
+
+		delay := t.backoff(attempt)
+		if res != nil {
+			// Rate limited responses tell us how long to wait
+			if retryAfter, ok := parseRetryAfter(res); ok {
+				delay = retryAfter
+			}
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the delay before the given retry, doubling from minBackoff up to
+// maxBackoff with jitter so that parallel requests don't retry in lockstep
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	delay := t.minBackoff << attempt
+	if delay <= 0 || delay > t.maxBackoff {
+		delay = t.maxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// parseRetryAfter reads the Retry-After header of a 429 or 503 response, which may hold
+// either a number of seconds or an HTTP date
+func parseRetryAfter(res *http.Response) (time.Duration, bool) {
+	if res.StatusCode != http.StatusTooManyRequests && res.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	value := res.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// isRetryable reports whether a request was rate limited or failed with a server error or a dropped connection
+func isRetryable(res *http.Response, err error) bool {
+	if err != nil {
+		return errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, syscall.ECONNREFUSED) ||
+			errors.Is(err, io.ErrUnexpectedEOF) ||
+			errors.Is(err, io.EOF)
+	}
+	if res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return res.StatusCode >= http.StatusInternalServerError && res.StatusCode != http.StatusNotImplemented
+}
+
+// canReplay reports whether the request body can be sent again
+func canReplay(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// timeoutTransport wraps an http.RoundTripper to bound how long each request may take,
+// including reading the response body. Creates get a separate, usually longer, timeout
+// because the server may provision resources before it responds.
+// Requests made within a resource's configured timeouts are bounded by those instead.
+type timeoutTransport struct {
+	base          http.RoundTripper
+	timeout       time.Duration
+	createTimeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	timeout := t.timeout
+	if req.Method == http.MethodPost {
+		timeout = t.createTimeout
+	}
+	if timeout <= 0 || hasOperationTimeout(req.Context()) {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	res, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Keep the deadline in place until the caller has finished with the body
+	res.Body = &cancelOnCloseBody{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// headersTransport wraps an http.RoundTripper to add a fixed set of headers to every request
+type headersTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headersTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// concurrencyTransport wraps an http.RoundTripper to limit how many requests are in flight at
+// once. A slot is held until the response body is closed.
+type concurrencyTransport struct {
+	base      http.RoundTripper
+	semaphore chan struct{}
+}
+
+func newConcurrencyTransport(base http.RoundTripper, limit int) *concurrencyTransport {
+	return &concurrencyTransport{
+		base:      base,
+		semaphore: make(chan struct{}, limit),
+	}
+}
+
+func (t *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.semaphore <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() { <-t.semaphore })
+	}
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	res.Body = &releaseOnCloseBody{ReadCloser: res.Body, release: release}
+	return res, nil
+}
+
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// httpURLPattern matches absolute http and https URLs
+var httpURLPattern = regexp.MustCompile(`^https?://[^/\s]+`)
+
+// httpURLValidator rejects values that aren't absolute http or https URLs
+func httpURLValidator() validator.String {
+	return stringvalidator.RegexMatches(httpURLPattern, "must be an absolute http or https URL")
+}
+
+// uuidPattern matches UUIDs in their canonical hyphenated form
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// uuidValidator rejects values that aren't UUIDs, so that malformed references to other
+// resources fail at validate time
+func uuidValidator() validator.String {
+	return stringvalidator.RegexMatches(uuidPattern, "must be a UUID")
+}